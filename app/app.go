@@ -3,26 +3,56 @@ package app
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
 	"github.com/thegeeklab/git-sv/sv"
 	"github.com/thegeeklab/git-sv/sv/formatter"
 	"github.com/thegeeklab/git-sv/templates"
 )
 
 const (
-	logSeparator = "###"
-	endLine      = "~~~"
+	logSeparator         = "###"
+	endLine              = "~~~"
+	defaultLogDateFormat = "2006-01-02"
+	logFormat            = "%aI" + logSeparator +
+		"%at" + logSeparator +
+		"%aN" + logSeparator +
+		"%aE" + logSeparator +
+		"%h" + logSeparator +
+		"%s" + logSeparator +
+		"%b" + endLine
+	// tagFormat tagger date, date of the tagged commit and commit date of lightweight tags.
+	tagFormat = "%(taggerdate:iso8601)#%(*committerdate:iso8601)#%(committerdate:iso8601)#%(refname:short)"
+	// versionFilePerm permission of version files written by WriteVersionFile.
+	versionFilePerm = 0o644
 )
 
-var errUnknownGitError = errors.New("git command failed")
+var (
+	errUnknownGitError = errors.New("git command failed")
+	errUnknownTag      = errors.New("unknown tag")
+	errInvalidTagRange = errors.New("invalid tag range")
+	errTagExists       = errors.New("tag already exists")
+	errNoTags          = errors.New("no tags found")
+	errUnknownCommit   = errors.New("unknown commit")
+	errUnknownRemote   = errors.New("unknown remote-tracking branch")
+	errNoCommits       = errors.New("repository has no commits")
+	errNoVersionField  = errors.New("no version field found")
+	errTagMismatch     = errors.New("tags do not match tag pattern")
+	errTagSign         = errors.New("could not sign tag")
+	errCommandConfig   = errors.New("could not merge config of command")
+)
 
 // Tag git tag info.
 type Tag struct {
@@ -30,6 +60,40 @@ type Tag struct {
 	Date time.Time
 }
 
+// TagsBetween return the tags after from up to and including to, tags must be sorted in ascending order.
+// The from tag is only included if inclusive is true.
+func TagsBetween(tags []Tag, from, to string, inclusive bool) ([]Tag, error) {
+	start, end := -1, -1
+
+	for i, tag := range tags {
+		if tag.Name == from {
+			start = i
+		}
+
+		if tag.Name == to {
+			end = i
+		}
+	}
+
+	if start < 0 {
+		return nil, fmt.Errorf("%w: %s not found, check tag filter", errUnknownTag, from)
+	}
+
+	if end < 0 {
+		return nil, fmt.Errorf("%w: %s not found, check tag filter", errUnknownTag, to)
+	}
+
+	if start > end {
+		return nil, fmt.Errorf("%w: %s is newer than %s", errInvalidTagRange, from, to)
+	}
+
+	if inclusive {
+		return tags[start : end+1], nil
+	}
+
+	return tags[start+1 : end+1], nil
+}
+
 // LogRangeType type of log range.
 type LogRangeType string
 
@@ -63,16 +127,18 @@ type GitSV struct {
 	OutputFormatter       formatter.OutputFormatter
 }
 
-// New constructor.
+// New constructor, the config is loaded by Load once the settings are set.
 func New() GitSV {
-	configDir := ".gitsv"
-	configFilenames := []string{"config.yaml", "config.yml"}
+	return GitSV{Settings: &Settings{}}
+}
 
-	g := GitSV{
-		Settings: &Settings{},
-		Config:   NewConfig(configDir, configFilenames),
-	}
+// Load return a copy with the config and templates of the repository root setting, the current directory
+// if no root is set.
+func (g GitSV) Load() GitSV {
+	configDir := filepath.Join(g.Settings.Root, ".gitsv")
+	configFilenames := []string{"config.yaml", "config.yml"}
 
+	g.Config = NewConfig(configDir, configFilenames)
 	g.MessageProcessor = sv.NewMessageProcessor(g.Config.CommitMessage, g.Config.Branches)
 	g.CommitProcessor = sv.NewSemVerCommitProcessor(g.Config.Versioning, g.Config.CommitMessage)
 	g.ReleasenotesProcessor = sv.NewReleaseNoteProcessor(g.Config.ReleaseNotes)
@@ -81,19 +147,74 @@ func New() GitSV {
 	return g
 }
 
-// LastTag get last tag, if no tag found, return empty.
+// Path resolve a relative path from the repository root setting, like the paths of the git commands.
+func (g GitSV) Path(name string) string {
+	if name == "" || filepath.IsAbs(name) {
+		return name
+	}
+
+	return filepath.Join(g.Settings.Root, name)
+}
+
+// ForCommand return a copy using the config overrides of the given command, processors are recreated
+// with the merged config.
+func (g GitSV) ForCommand(name string) (GitSV, error) {
+	if _, exists := g.Config.Commands[name]; !exists {
+		return g, nil
+	}
+
+	cfg, err := g.Config.ForCommand(name)
+	if err != nil {
+		return g, fmt.Errorf("%w %s: %w", errCommandConfig, name, err)
+	}
+
+	g.Config = cfg
+	g.MessageProcessor = sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches)
+	g.CommitProcessor = sv.NewSemVerCommitProcessor(cfg.Versioning, cfg.CommitMessage)
+	g.ReleasenotesProcessor = sv.NewReleaseNoteProcessor(cfg.ReleaseNotes)
+
+	return g, nil
+}
+
+// FetchRemoteTags fetch the tags of all remotes if remote tags are enabled. It is run once before a command,
+// so the fetched tags are used like local tags, remotes which can not be fetched are ignored.
+// The fetched tags are written to the local refs/tags, credential prompts are disabled so a remote
+// requiring authentication fails instead of waiting for input.
+func (g GitSV) FetchRemoteTags() error {
+	if !g.Settings.RemoteTags && !enabled(g.Config.Tag.RemoteTags) {
+		return nil
+	}
+
+	out, err := g.gitCommand("remote").CombinedOutput()
+	if err != nil {
+		return combinedOutputErr(err, out)
+	}
+
+	for _, remote := range strings.Fields(string(out)) {
+		cmd := g.gitCommand("fetch", "--quiet", "--tags", remote)
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+		if fetchOut, err := cmd.CombinedOutput(); err != nil {
+			log.Warn().Err(combinedOutputErr(err, fetchOut)).Msgf("could not fetch tags from %s", remote)
+		}
+	}
+
+	return nil
+}
+
+// LastTag get last tag according to the configured sort key, if no tag found, return empty.
 func (g GitSV) LastTag() string {
-	//nolint:gosec
-	cmd := exec.Command(
-		"git",
+	if g.Config.Tag.SortBy == TagSortCommitterDate {
+		return g.lastTagByCommitterDate()
+	}
+
+	cmd := g.gitCommand(
 		"for-each-ref",
 		fmt.Sprintf("refs/tags/%s", *g.Config.Tag.Filter),
 		"--sort",
 		"-creatordate",
 		"--format",
-		"%(refname:short)",
-		"--count",
-		"1",
+		tagFormat,
 	)
 
 	out, err := cmd.CombinedOutput()
@@ -101,18 +222,56 @@ func (g GitSV) LastTag() string {
 		return ""
 	}
 
-	return strings.TrimSpace(strings.Trim(string(out), "\n"))
+	tags, _ := parseTagsOutput(string(out))
+
+	var last *Tag
+
+	// input is sorted by creation date, keep the first tag on equal dates
+	for i, tag := range tags {
+		if !g.skipTag(tag.Name) && (last == nil || tag.Date.After(last.Date)) {
+			last = &tags[i]
+		}
+	}
+
+	if last == nil {
+		return ""
+	}
+
+	return last.Name
 }
 
-// Log return git log.
+// lastTagByCommitterDate return the tag pointing to the commit with the latest committer date,
+// ties are resolved by the tag creation date.
+func (g GitSV) lastTagByCommitterDate() string {
+	cmd := g.gitCommand(
+		"for-each-ref",
+		fmt.Sprintf("refs/tags/%s", *g.Config.Tag.Filter),
+		"--sort",
+		"-creatordate",
+		"--format",
+		"%(committerdate:unix)#%(*committerdate:unix)#%(refname:short)",
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return ""
+	}
+
+	return parseLastTagByCommitterDate(string(out), g.skipTag)
+}
+
+func (g GitSV) excludePrereleases() bool {
+	return g.Settings.ExcludePrereleases || enabled(g.Config.Tag.ExcludePrereleases)
+}
+
+// skipTag check if tag should be ignored according to the prerelease settings.
+func (g GitSV) skipTag(name string) bool {
+	return g.excludePrereleases() && sv.IsPrereleaseVersion(name)
+}
+
+// Log return git log, names and emails are canonicalized with the repository .mailmap.
 func (g GitSV) Log(lr LogRange) ([]sv.CommitLog, error) {
-	format := "--pretty=format:\"%ad" + logSeparator +
-		"%at" + logSeparator +
-		"%cN" + logSeparator +
-		"%h" + logSeparator +
-		"%s" + logSeparator +
-		"%b" + endLine + "\""
-	params := []string{"log", "--date=short", format}
+	params := []string{"log", "--pretty=format:\"" + logFormat + "\""}
 
 	if lr.start != "" || lr.end != "" {
 		switch lr.rangeType {
@@ -127,41 +286,466 @@ func (g GitSV) Log(lr LogRange) ([]sv.CommitLog, error) {
 		}
 	}
 
-	cmd := exec.Command("git", params...)
+	cmd := g.gitCommand(params...)
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
+		if !g.HasCommits() {
+			log.Warn().Msg("repository has no commits, git log is empty")
+
+			return nil, nil
+		}
+
 		return nil, combinedOutputErr(err, out)
 	}
 
-	logs, parseErr := parseLogOutput(g.MessageProcessor, string(out))
+	logs, parseErr := parseLogOutput(g.MessageProcessor, string(out), g.Config.LogDateFormat)
 	if parseErr != nil {
 		return nil, parseErr
 	}
 
-	return logs, nil
+	return slices.DeleteFunc(logs, g.ignoreCommit), nil
+}
+
+// ignoreCommit check if the commit scope is listed in ignore-scopes, ignored commits are neither
+// used for versioning nor for notes.
+func (g GitSV) ignoreCommit(commit sv.CommitLog) bool {
+	return commit.Message.Scope != "" && slices.Contains(g.Config.IgnoreScopes, commit.Message.Scope)
+}
+
+// LogByTag return the commits of each tag range with a single git log walk, tags must be sorted newest
+// first. Like the range between a tag and the previous one, a commit belongs to a tag if it is reachable
+// from the tag but not from the previous tag.
+func (g GitSV) LogByTag(tags []Tag) (map[string][]sv.CommitLog, error) {
+	result := make(map[string][]sv.CommitLog, len(tags))
+	if len(tags) == 0 {
+		return result, nil
+	}
+
+	index := make(map[string]int, len(tags))
+	params := []string{
+		"log", "--decorate-refs=refs/tags/",
+		"--pretty=format:\"%D" + logSeparator + "%H %P" + logSeparator + logFormat + "\"",
+	}
+
+	for i, tag := range tags {
+		index[tag.Name] = i
+		params = append(params, "refs/tags/"+tag.Name)
+	}
+
+	params = append(params, "--")
+
+	out, err := g.gitCommand(params...).CombinedOutput()
+	if err != nil {
+		return nil, combinedOutputErr(err, out)
+	}
+
+	nodes, err := parseLogGraph(g.MessageProcessor, string(out), g.Config.LogDateFormat, index)
+	if err != nil {
+		return nil, err
+	}
+
+	propagateTagReachability(nodes)
+
+	for _, node := range nodes {
+		if g.ignoreCommit(node.commit) {
+			continue
+		}
+
+		for i, tag := range tags {
+			if node.reachable.has(i) && (i+1 == len(tags) || !node.reachable.has(i+1)) {
+				result[tag.Name] = append(result[tag.Name], node.commit)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// logNode commit of a log walk with its parents and the set of tags it is reachable from.
+type logNode struct {
+	commit    sv.CommitLog
+	hash      string
+	parents   []string
+	reachable tagSet
+}
+
+// tagSet bitset of tag indexes.
+type tagSet []uint64
+
+func newTagSet(size int) tagSet {
+	return make(tagSet, (size+63)/64) //nolint:mnd
+}
+
+func (s tagSet) add(i int) {
+	s[i/64] |= 1 << (i % 64) //nolint:mnd
+}
+
+func (s tagSet) has(i int) bool {
+	return s[i/64]&(1<<(i%64)) != 0 //nolint:mnd
+}
+
+func (s tagSet) union(other tagSet) {
+	for i := range s {
+		s[i] |= other[i]
+	}
+}
+
+// parseLogGraph parse a git log with tag decorations, hash and parents, nodes are returned in log order
+// and are marked as reachable from the tags pointing to them.
+func parseLogGraph(
+	messageProcessor sv.MessageProcessor, out, dateFormat string, index map[string]int,
+) ([]*logNode, error) {
+	var nodes []*logNode
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Split(splitAt([]byte(endLine)))
+
+	for scanner.Scan() {
+		text := strings.Trim(strings.TrimSpace(strings.Trim(scanner.Text(), "\"")), "\"")
+		if text == "" {
+			continue
+		}
+
+		refs, rest, _ := strings.Cut(text, logSeparator)
+		hashes, content, _ := strings.Cut(rest, logSeparator)
+
+		commit, err := parseCommitLog(messageProcessor, content, dateFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &logNode{commit: commit, reachable: newTagSet(len(index))}
+		if fields := strings.Fields(hashes); len(fields) > 0 {
+			node.hash, node.parents = fields[0], fields[1:]
+		}
+
+		for _, name := range parseTagDecorations(refs) {
+			if i, exists := index[name]; exists {
+				node.reachable.add(i)
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// propagateTagReachability mark the parents of each node as reachable from the tags of the node,
+// nodes are visited in topological order, children before their parents.
+func propagateTagReachability(nodes []*logNode) {
+	byHash := make(map[string]*logNode, len(nodes))
+	children := make(map[string]int, len(nodes))
+
+	for _, node := range nodes {
+		byHash[node.hash] = node
+
+		for _, parent := range node.parents {
+			children[parent]++
+		}
+	}
+
+	queue := make([]*logNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		if children[node.hash] == 0 {
+			queue = append(queue, node)
+		}
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, hash := range node.parents {
+			parent, exists := byHash[hash]
+			if !exists {
+				continue
+			}
+
+			parent.reachable.union(node.reachable)
+
+			if children[hash]--; children[hash] == 0 {
+				queue = append(queue, parent)
+			}
+		}
+	}
+}
+
+// parseTagDecorations return the tag names of a git log %D decoration.
+func parseTagDecorations(refs string) []string {
+	var names []string
+
+	for _, ref := range strings.Split(refs, ",") {
+		if name, found := strings.CutPrefix(strings.TrimSpace(ref), "tag: "); found {
+			names = append(names, name)
+		}
+	}
+
+	return names
 }
 
-// Commit runs git sv.
-func (g GitSV) Commit(header, body, footer string) error {
-	cmd := exec.Command("git", "commit", "-m", header, "-m", "", "-m", body, "-m", "", "-m", footer)
+// HasCommits return true if HEAD points to a commit, false for a freshly initialized repository.
+func (g GitSV) HasCommits() bool {
+	_, err := g.resolveCommit("HEAD")
+
+	return err == nil
+}
+
+// FileVersion return the content of the configured version file, empty if no version file is configured.
+// For json files, e.g. package.json, the value of the top-level "version" field is returned instead.
+// Relative paths are resolved from the repository root setting.
+func (g GitSV) FileVersion() (string, error) {
+	name := g.Path(g.Config.Tag.VersionFile)
+	if name == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("could not read version file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		start, end, err := jsonVersionOffsets(content)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, name)
+		}
+
+		var version string
+		if err := json.Unmarshal(content[start:end], &version); err != nil {
+			return "", fmt.Errorf("%w: %s", errNoVersionField, name)
+		}
+
+		return strings.TrimSpace(version), nil
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// WriteVersionFile write version to the file name, relative to the repository root. For json files,
+// e.g. package.json, only the value of the top-level "version" field is replaced, other files are overwritten.
+// The file is replaced atomically, it is left unchanged if writing fails.
+func (g GitSV) WriteVersionFile(name, version string) error {
+	name = g.Path(name)
+
+	content := []byte(version + "\n")
+
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		current, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("could not read version file: %w", err)
+		}
+
+		start, end, err := jsonVersionOffsets(current)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, name)
+		}
+
+		value, _ := json.Marshal(version)
+		content = slices.Concat(current[:start], value, current[end:])
+	}
+
+	if err := writeFileAtomic(name, content); err != nil {
+		return fmt.Errorf("could not write version file: %w", err)
+	}
+
+	return nil
+}
+
+// jsonVersionOffsets return the start and end offset of the string value of the top-level "version" field
+// of a json object.
+func jsonVersionOffsets(content []byte) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return 0, 0, errNoVersionField
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", errNoVersionField, err.Error())
+		}
+
+		start := int(dec.InputOffset())
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", errNoVersionField, err.Error())
+		}
+
+		var version string
+		if key != "version" || json.Unmarshal(value, &version) != nil {
+			continue
+		}
+
+		// the value is preceded by the colon and whitespaces
+		end := int(dec.InputOffset())
+		start += bytes.IndexByte(content[start:end], '"')
+
+		return start, end, nil
+	}
+
+	return 0, 0, errNoVersionField
+}
+
+// writeFileAtomic write content to a temporary file and rename it to name, keeping the mode of an
+// existing file.
+func writeFileAtomic(name string, content []byte) error {
+	perm := os.FileMode(versionFilePerm)
+	if info, err := os.Stat(name); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Add stage the given paths, relative to the repository root.
+func (g GitSV) Add(paths ...string) error {
+	if out, err := g.gitCommand(append([]string{"add", "--"}, paths...)...).CombinedOutput(); err != nil {
+		return combinedOutputErr(err, out)
+	}
+
+	return nil
+}
+
+// Unstage reset the index of the given paths to HEAD, relative to the repository root.
+func (g GitSV) Unstage(paths ...string) error {
+	if out, err := g.gitCommand(append([]string{"reset", "-q", "--"}, paths...)...).CombinedOutput(); err != nil {
+		return combinedOutputErr(err, out)
+	}
+
+	return nil
+}
+
+// CommitPaths commit only the given paths with the header as message, changes of other staged paths
+// are kept in the index.
+func (g GitSV) CommitPaths(header string, paths ...string) error {
+	args := append([]string{"commit", "--only", "-m", header, "--"}, paths...)
+	if out, err := g.gitCommand(args...).CombinedOutput(); err != nil {
+		return combinedOutputErr(err, out)
+	}
+
+	return nil
+}
+
+// RangeStats return the file change statistics aggregated over the commits of the range from..to,
+// files changed by several commits are counted once. All commits reachable from to are used if from is empty.
+func (g GitSV) RangeStats(from, to string) (sv.CommitStats, error) {
+	revision := to
+	if from != "" {
+		revision = from + ".." + to
+	}
+
+	out, err := g.gitCommand("log", "--numstat", "--format=", revision, "--").CombinedOutput()
+	if err != nil {
+		return sv.CommitStats{}, combinedOutputErr(err, out)
+	}
+
+	return parseNumstatOutput(string(out)), nil
+}
+
+// CommitStats return file change statistics of a commit compared to its parent.
+func (g GitSV) CommitStats(hash string) (sv.CommitStats, error) {
+	cmd := g.gitCommand("show", "--numstat", "--format=", hash)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return sv.CommitStats{}, combinedOutputErr(err, out)
+	}
+
+	return parseNumstatOutput(string(out)), nil
+}
+
+// Commit runs git commit with the message assembled by sv.JoinMessage, allowEmpty permits a commit without changes.
+func (g GitSV) Commit(header, body, footer string, allowEmpty bool) error {
+	args := []string{"commit", "-m", sv.JoinMessage(header, body, footer)}
+	if allowEmpty {
+		args = append(args, "--allow-empty")
+	}
+
+	cmd := g.gitCommand(args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	return cmd.Run()
 }
 
-// Tag create a git tag.
-func (g GitSV) Tag(version semver.Version, annotate, local bool) (string, error) {
+// Tag create a git tag for version at the given commit, HEAD is used if commit is empty.
+func (g GitSV) Tag(version semver.Version, annotate, local bool, commit string) (string, error) {
 	tag := fmt.Sprintf(*g.Config.Tag.Pattern, version.Major(), version.Minor(), version.Patch())
-	tagMsg := fmt.Sprintf("Version %d.%d.%d", version.Major(), version.Minor(), version.Patch())
+	tagMsg := fmt.Sprintf(
+		str(g.Config.Tag.MessageFormat, DefaultTagMessageFormat), version.Major(), version.Minor(), version.Patch(),
+	)
+
+	if preRelease := version.Prerelease(); preRelease != "" {
+		tag += "-" + preRelease
+		tagMsg += "-" + preRelease
+	}
+
+	if !g.HasCommits() {
+		return tag, errNoCommits
+	}
+
+	tags, err := g.Tags()
+	if err != nil {
+		return tag, err
+	}
+
+	if existing, found := findVersionTag(tags, tag, version); found {
+		return tag, fmt.Errorf(
+			"%w: version %d.%d.%d already tagged as %s",
+			errTagExists, version.Major(), version.Minor(), version.Patch(), existing,
+		)
+	}
+
+	tagCommand := g.gitCommand("tag", tag)
 
-	tagCommand := exec.Command("git", "tag", tag)
-	if annotate {
+	switch {
+	case enabled(g.Config.Tag.Sign) && g.Config.Tag.SigningKey != "":
+		tagCommand.Args = append(tagCommand.Args, "-u", g.Config.Tag.SigningKey, "-m", tagMsg)
+	case enabled(g.Config.Tag.Sign):
+		tagCommand.Args = append(tagCommand.Args, "-s", "-m", tagMsg)
+	case annotate:
 		tagCommand.Args = append(tagCommand.Args, "-a", "-m", tagMsg)
 	}
 
+	if commit != "" {
+		hash, err := g.resolveCommit(commit)
+		if err != nil {
+			return tag, err
+		}
+
+		tagCommand.Args = append(tagCommand.Args, hash)
+	}
+
 	if out, err := tagCommand.CombinedOutput(); err != nil {
+		if enabled(g.Config.Tag.Sign) {
+			return tag, fmt.Errorf("%w %s: %w", errTagSign, tag, combinedOutputErr(err, out))
+		}
+
 		return tag, combinedOutputErr(err, out)
 	}
 
@@ -169,7 +753,7 @@ func (g GitSV) Tag(version semver.Version, annotate, local bool) (string, error)
 		return tag, nil
 	}
 
-	pushCommand := exec.Command("git", "push", "origin", tag)
+	pushCommand := g.gitCommand("push", "origin", tag)
 	if out, err := pushCommand.CombinedOutput(); err != nil {
 		return tag, combinedOutputErr(err, out)
 	}
@@ -177,16 +761,81 @@ func (g GitSV) Tag(version semver.Version, annotate, local bool) (string, error)
 	return tag, nil
 }
 
+// RemoteBranch return the full ref of a remote-tracking branch like origin/main, the branch must
+// be fetched before.
+func (g GitSV) RemoteBranch(name string) (string, error) {
+	ref := "refs/remotes/" + name
+	if _, err := g.resolveCommit(ref); err != nil {
+		return "", fmt.Errorf("%w: %s", errUnknownRemote, name)
+	}
+
+	return ref, nil
+}
+
+// resolveCommit return the full hash of the commit referenced by ref.
+func (g GitSV) resolveCommit(ref string) (string, error) {
+	out, err := g.gitCommand("rev-parse", "--verify", "--quiet", ref+"^{commit}").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errUnknownCommit, ref)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
 // Tags list repository tags.
 func (g GitSV) Tags() ([]Tag, error) {
-	//nolint:gosec
-	cmd := exec.Command(
-		"git",
+	tags, err := g.allTags()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Tag, 0, len(tags))
+
+	for _, tag := range tags {
+		if !g.skipTag(tag.Name) {
+			result = append(result, tag)
+		}
+	}
+
+	return result, nil
+}
+
+// LastPreReleaseTag return the tag of the highest pre-release of version with the given name, e.g. 1.2.0-rc.2
+// for 1.2.0 and rc. Pre-release tags are considered even if they are excluded, return empty if there is none.
+func (g GitSV) LastPreReleaseTag(version semver.Version, name string) (string, error) {
+	tags, err := g.allTags()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		result  string
+		highest *semver.Version
+	)
+
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag.Name)
+		if err != nil || v.Major() != version.Major() || v.Minor() != version.Minor() ||
+			v.Patch() != version.Patch() || !strings.HasPrefix(v.Prerelease(), name+".") {
+			continue
+		}
+
+		if highest == nil || v.GreaterThan(highest) {
+			result, highest = tag.Name, v
+		}
+	}
+
+	return result, nil
+}
+
+// allTags list all repository tags matching the tag filter, including excluded pre-release tags.
+func (g GitSV) allTags() ([]Tag, error) {
+	cmd := g.gitCommand(
 		"for-each-ref",
 		"--sort",
 		"creatordate",
 		"--format",
-		"%(creatordate:iso8601)#%(refname:short)",
+		tagFormat,
 		fmt.Sprintf("refs/tags/%s", *g.Config.Tag.Filter),
 	)
 
@@ -195,12 +844,41 @@ func (g GitSV) Tags() ([]Tag, error) {
 		return nil, combinedOutputErr(err, out)
 	}
 
-	return parseTagsOutput(string(out))
+	tags, err := parseTagsOutput(string(out))
+	if err != nil {
+		return nil, err
+	}
+
+	// malformed annotated tags have no creation date, keep them in order of the resolved date
+	slices.SortStableFunc(tags, func(a, b Tag) int { return a.Date.Compare(b.Date) })
+
+	return tags, nil
+}
+
+// LatestReleaseRange return the two most recent tags, prev is empty if there is only one tag.
+func (g GitSV) LatestReleaseRange() (string, string, error) {
+	tags, err := g.Tags()
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(tags) == 0 {
+		return "", "", fmt.Errorf("%w: check tag filter", errNoTags)
+	}
+
+	current := tags[len(tags)-1].Name
+
+	prev := ""
+	if len(tags) > 1 {
+		prev = tags[len(tags)-2].Name
+	}
+
+	return prev, current, nil
 }
 
 // Branch get git branch.
 func (g GitSV) Branch() string {
-	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+	cmd := g.gitCommand("symbolic-ref", "--short", "HEAD")
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -212,7 +890,7 @@ func (g GitSV) Branch() string {
 
 // IsDetached check if is detached.
 func (g GitSV) IsDetached() (bool, error) {
-	cmd := exec.Command("git", "symbolic-ref", "-q", "HEAD")
+	cmd := g.gitCommand("symbolic-ref", "-q", "HEAD")
 
 	out, err := cmd.CombinedOutput()
 	// -q: do not issue an error message if the <name> is not a symbolic ref, but a detached HEAD;
@@ -228,23 +906,156 @@ func (g GitSV) IsDetached() (bool, error) {
 	return false, nil
 }
 
+// TagDiagnostics return a warning if existing tags don't match the configured tag pattern, those tags are
+// still used as last tag, but tags created by git-sv will not be comparable to them.
+func (g GitSV) TagDiagnostics() []error {
+	tags, err := g.Tags()
+	if err != nil {
+		log.Debug().Err(err).Msg("could not list tags to check tag pattern")
+
+		return nil
+	}
+
+	pattern := tagPatternRegex(*g.Config.Tag.Pattern)
+	mismatched := make([]string, 0)
+
+	for _, tag := range tags {
+		if !pattern.MatchString(tag.Name) {
+			mismatched = append(mismatched, tag.Name)
+		}
+	}
+
+	if len(mismatched) == 0 {
+		return nil
+	}
+
+	return []error{fmt.Errorf(
+		"%w: %s in [%s]", errTagMismatch, *g.Config.Tag.Pattern, strings.Join(mismatched, ", "),
+	)}
+}
+
+// tagPatternRegex convert a tag pattern like v%d.%d.%d to a regex matching the tags created with it,
+// including an optional pre-release suffix.
+func tagPatternRegex(pattern string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), "%d", "[0-9]+")
+
+	return regexp.MustCompile("^" + expr + "(-.+)?$")
+}
+
+func findVersionTag(tags []Tag, name string, version semver.Version) (string, bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag.Name, true
+		}
+
+		if v, err := semver.NewVersion(tag.Name); err == nil && v.Equal(&version) {
+			return tag.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func (g GitSV) gitCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...) //nolint:gosec
+	cmd.Dir = g.Settings.Root
+
+	return cmd
+}
+
+// parseTagsOutput parse tags in tagFormat, the date is resolved from the tagger date, the date of the
+// tagged commit or the commit date of lightweight tags, the first valid one is used.
 func parseTagsOutput(input string) ([]Tag, error) {
 	scanner := bufio.NewScanner(strings.NewReader(input))
 
 	var result []Tag
 
 	for scanner.Scan() {
-		if line := strings.TrimSpace(scanner.Text()); line != "" {
-			values := strings.Split(line, "#")
-			date, _ := time.Parse("2006-01-02 15:04:05 -0700", values[0]) // ignore invalid dates
-			result = append(result, Tag{Name: values[1], Date: date})
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
 		}
+
+		values := strings.SplitN(line, "#", 4) //nolint:mnd
+		name := values[len(values)-1]
+
+		var date time.Time
+
+		for _, value := range values[:len(values)-1] {
+			// ignore invalid dates
+			if parsed, err := time.Parse("2006-01-02 15:04:05 -0700", value); err == nil && !parsed.IsZero() {
+				date = parsed
+
+				break
+			}
+		}
+
+		result = append(result, Tag{Name: name, Date: date})
 	}
 
 	return result, nil
 }
 
-func parseLogOutput(messageProcessor sv.MessageProcessor, log string) ([]sv.CommitLog, error) {
+func parseLastTagByCommitterDate(input string, skip func(string) bool) string {
+	scanner := bufio.NewScanner(strings.NewReader(input))
+
+	var (
+		result string
+		latest int64 = -1
+	)
+
+	// committer date, committer date of the dereferenced commit and tag name, which may contain #
+	const fields = 3
+
+	for scanner.Scan() {
+		values := strings.SplitN(strings.TrimSpace(scanner.Text()), "#", fields)
+		if len(values) != fields || skip(values[2]) {
+			continue
+		}
+
+		// annotated tags only expose the committer date of the dereferenced commit
+		date, err := strconv.ParseInt(str(values[0], values[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// input is sorted by creation date, keep the first tag on equal committer dates
+		if date > latest {
+			result = values[2]
+			latest = date
+		}
+	}
+
+	return result
+}
+
+func parseNumstatOutput(input string) sv.CommitStats {
+	scanner := bufio.NewScanner(strings.NewReader(input))
+
+	var stats sv.CommitStats
+
+	files := make(map[string]struct{})
+
+	for scanner.Scan() {
+		values := strings.Split(scanner.Text(), "\t")
+		if len(values) < 3 { //nolint:mnd
+			continue
+		}
+
+		// binary files are listed with "-" instead of line counts
+		insertions, _ := strconv.Atoi(values[0])
+		deletions, _ := strconv.Atoi(values[1])
+
+		files[values[2]] = struct{}{}
+		stats.FilesChanged = len(files)
+		stats.Insertions += insertions
+		stats.Deletions += deletions
+	}
+
+	return stats
+}
+
+func parseLogOutput(messageProcessor sv.MessageProcessor, log, dateFormat string) ([]sv.CommitLog, error) {
 	scanner := bufio.NewScanner(strings.NewReader(log))
 	scanner.Split(splitAt([]byte(endLine)))
 
@@ -252,7 +1063,7 @@ func parseLogOutput(messageProcessor sv.MessageProcessor, log string) ([]sv.Comm
 
 	for scanner.Scan() {
 		if text := strings.TrimSpace(strings.Trim(scanner.Text(), "\"")); text != "" {
-			log, err := parseCommitLog(messageProcessor, text)
+			log, err := parseCommitLog(messageProcessor, text, dateFormat)
 			if err != nil {
 				return nil, err
 			}
@@ -264,24 +1075,34 @@ func parseLogOutput(messageProcessor sv.MessageProcessor, log string) ([]sv.Comm
 	return logs, nil
 }
 
-func parseCommitLog(messageProcessor sv.MessageProcessor, c string) (sv.CommitLog, error) {
+func parseCommitLog(messageProcessor sv.MessageProcessor, c, dateFormat string) (sv.CommitLog, error) {
 	content := strings.Split(strings.Trim(c, "\""), logSeparator)
 	timestamp, _ := strconv.Atoi(content[1])
 
-	message, err := messageProcessor.Parse(content[4], content[5])
+	message, err := messageProcessor.Parse(content[5], content[6])
 	if err != nil {
 		return sv.CommitLog{}, err
 	}
 
 	return sv.CommitLog{
-		Date:       content[0],
-		Timestamp:  timestamp,
-		AuthorName: content[2],
-		Hash:       content[3],
-		Message:    message,
+		Date:        formatLogDate(content[0], dateFormat),
+		Timestamp:   timestamp,
+		AuthorName:  content[2],
+		AuthorEmail: content[3],
+		Hash:        content[4],
+		Message:     message,
 	}, nil
 }
 
+func formatLogDate(value, dateFormat string) string {
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil { // keep original value if is not a strict iso date
+		return value
+	}
+
+	return date.Format(str(dateFormat, defaultLogDateFormat))
+}
+
 func splitAt(b []byte) func(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	return func(data []byte, atEOF bool) (advance int, token []byte, err error) { //nolint:nonamedreturns
 		if atEOF && len(data) == 0 {
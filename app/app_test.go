@@ -1,9 +1,18 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/thegeeklab/git-sv/sv"
 )
 
 func Test_parseTagsOutput(t *testing.T) {
@@ -14,14 +23,26 @@ func Test_parseTagsOutput(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			"with date",
-			"2020-05-01 18:00:00 -0300#1.0.0",
+			"annotated tag",
+			"2020-05-01 18:00:00 -0300#2020-05-01 17:00:00 -0300##1.0.0",
 			[]Tag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}},
 			false,
 		},
+		{
+			"annotated tag without tagger date",
+			"#2020-05-01 17:00:00 -0300##1.0.0",
+			[]Tag{{Name: "1.0.0", Date: date("2020-05-01 17:00:00 -0300")}},
+			false,
+		},
+		{
+			"lightweight tag",
+			"##2020-05-01 16:00:00 -0300#1.0.0",
+			[]Tag{{Name: "1.0.0", Date: date("2020-05-01 16:00:00 -0300")}},
+			false,
+		},
 		{
 			"without date",
-			"#1.0.0",
+			"###1.0.0",
 			[]Tag{{Name: "1.0.0", Date: time.Time{}}},
 			false,
 		},
@@ -42,11 +63,1229 @@ func Test_parseTagsOutput(t *testing.T) {
 	}
 }
 
-func date(input string) time.Time {
-	t, err := time.Parse("2006-01-02 15:04:05 -0700", input)
+func Test_parseLogOutput(t *testing.T) {
+	input := "\"2020-05-01T18:00:00-03:00###1588366800###author###author@example.com###abc123###" +
+		"feat: add something###~~~\""
+
+	tests := []struct {
+		name       string
+		dateFormat string
+		want       string
+	}{
+		{"default format", "", "2020-05-01"},
+		{"custom format", "02.01.2006 15:04", "01.05.2020 18:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := sv.NewMessageProcessor(sv.CommitMessageConfig{}, sv.BranchesConfig{})
+
+			got, err := parseLogOutput(p, input, tt.dateFormat)
+			if err != nil {
+				t.Errorf("parseLogOutput() error = %v", err)
+
+				return
+			}
+
+			if len(got) != 1 || got[0].Date != tt.want {
+				t.Errorf("parseLogOutput() = %v, want date %v", got, tt.want)
+
+				return
+			}
+
+			if got[0].AuthorEmail != "author@example.com" {
+				t.Errorf("parseLogOutput() AuthorEmail = %v, want %v", got[0].AuthorEmail, "author@example.com")
+			}
+		})
+	}
+}
+
+func TestTagsBetween(t *testing.T) {
+	tags := []Tag{{Name: "v1.0.0"}, {Name: "v1.0.1"}, {Name: "v1.0.2"}, {Name: "v1.1.0"}, {Name: "v1.2.0"}}
+
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		inclusive bool
+		want      []Tag
+		wantErr   bool
+	}{
+		{
+			"exclusive", "v1.0.0", "v1.1.0", false,
+			[]Tag{{Name: "v1.0.1"}, {Name: "v1.0.2"}, {Name: "v1.1.0"}}, false,
+		},
+		{
+			"inclusive", "v1.0.0", "v1.1.0", true,
+			[]Tag{{Name: "v1.0.0"}, {Name: "v1.0.1"}, {Name: "v1.0.2"}, {Name: "v1.1.0"}}, false,
+		},
+		{"adjacent exclusive", "v1.1.0", "v1.2.0", false, []Tag{{Name: "v1.2.0"}}, false},
+		{"same tag exclusive", "v1.1.0", "v1.1.0", false, []Tag{}, false},
+		{"same tag inclusive", "v1.1.0", "v1.1.0", true, []Tag{{Name: "v1.1.0"}}, false},
+		{"reversed range", "v1.1.0", "v1.0.0", false, nil, true},
+		{"unknown from", "v0.1.0", "v1.0.0", false, nil, true},
+		{"unknown to", "v1.0.0", "v9.0.0", false, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TagsBetween(tags, tt.from, tt.to, tt.inclusive)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TagsBetween() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagsBetween() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_TagExisting(t *testing.T) {
+	newTestRepo(t)
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "v1.0.0")
+
+	g := newTestGitSV()
+	pattern := "%d.%d.%d"
+	g.Config.Tag.Pattern = &pattern
+
+	_, err := g.Tag(*semver.MustParse("1.0.0"), false, true, "")
+	if !errors.Is(err, errTagExists) {
+		t.Fatalf("GitSV.Tag() error = %v, want %v", err, errTagExists)
+	}
+
+	if want := "tag already exists: version 1.0.0 already tagged as v1.0.0"; err.Error() != want {
+		t.Errorf("GitSV.Tag() error = %v, want %v", err, want)
+	}
+
+	if _, err := g.Tag(*semver.MustParse("1.1.0"), false, true, ""); err != nil {
+		t.Errorf("GitSV.Tag() error = %v", err)
+	}
+}
+
+func TestGitSV_TagSign(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg not available")
+	}
+
+	newTestRepo(t)
+	gitCommit(t, "feat: first feature")
+
+	// keep the gpg home short, the agent socket path is limited in length
+	gpgHome, err := os.MkdirTemp("", "gpg")
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
 	}
 
-	return t
+	t.Setenv("GNUPGHOME", gpgHome)
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--kill", "gpg-agent").Run()
+		os.RemoveAll(gpgHome)
+	})
+
+	if out, err := exec.Command(
+		"gpg", "--batch", "--passphrase", "", "--quick-gen-key", "committer <committer@example.com>",
+		"ed25519", "sign", "never",
+	).CombinedOutput(); err != nil {
+		t.Skipf("could not generate gpg key: %v: %s", err, out)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		version string
+		wantErr error
+	}{
+		{"committer key", "", "1.0.0", nil},
+		{"signing key", "committer@example.com", "1.1.0", nil},
+		{"unknown signing key", "unknown@example.com", "1.2.0", errTagSign},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV()
+			sign := true
+			g.Config.Tag.Sign = &sign
+			g.Config.Tag.SigningKey = tt.key
+
+			tag, err := g.Tag(*semver.MustParse(tt.version), false, true, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GitSV.Tag() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if _, err := g.resolveCommit("refs/tags/" + tag); err == nil {
+					t.Errorf("GitSV.Tag() created unsigned tag %s", tag)
+				}
+
+				return
+			}
+
+			if got := git(t, "cat-file", "tag", tag); !strings.Contains(got, "-----BEGIN PGP SIGNATURE-----") {
+				t.Errorf("GitSV.Tag() tag object = %s, want a PGP signature", got)
+			}
+		})
+	}
+}
+
+func TestGitSV_ForCommand(t *testing.T) {
+	g := newTestGitSV()
+	g.Config.Commands = map[string]Config{
+		"changelog": {ReleaseNotes: sv.ReleaseNotesConfig{Sections: []sv.ReleaseNotesSectionConfig{
+			{Name: "Changes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+		}}},
+	}
+	g.ReleasenotesProcessor = sv.NewReleaseNoteProcessor(g.Config.ReleaseNotes)
+
+	commits := []sv.CommitLog{sv.TestCommitlog("feat", map[string]string{}, "a")}
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"changelog", "Changes"},
+		{"release-notes", "Features"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			cmdGsv, err := g.ForCommand(tt.command)
+			if err != nil {
+				t.Fatalf("GitSV.ForCommand() error = %v", err)
+			}
+
+			releaseNote := cmdGsv.ReleasenotesProcessor.Create(nil, "", time.Time{}, commits)
+
+			section, ok := releaseNote.Sections[0].(sv.ReleaseNoteCommitsSection)
+			if !ok || section.Name != tt.want {
+				t.Errorf("GitSV.ForCommand() sections = %+v, want %s", releaseNote.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_TagCommit(t *testing.T) {
+	newTestRepo(t)
+	gitCommit(t, "feat: first feature")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "fix: second fix")
+
+	g := newTestGitSV()
+	want := git(t, "rev-parse", "HEAD~2")
+
+	tests := []struct {
+		name     string
+		version  string
+		annotate bool
+	}{
+		{"lightweight tag", "1.0.0", false},
+		{"annotated tag", "1.1.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := g.Tag(*semver.MustParse(tt.version), tt.annotate, true, "HEAD~2")
+			if err != nil {
+				t.Fatalf("GitSV.Tag() error = %v", err)
+			}
+
+			if got := git(t, "rev-parse", tag+"^{commit}"); got != want {
+				t.Errorf("GitSV.Tag() points to %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := g.Tag(*semver.MustParse("2.0.0"), false, true, "unknown"); !errors.Is(err, errUnknownCommit) {
+		t.Errorf("GitSV.Tag() error = %v, want %v", err, errUnknownCommit)
+	}
+}
+
+func TestGitSV_TagMessageFormat(t *testing.T) {
+	newTestRepo(t)
+	gitCommit(t, "feat: first feature")
+
+	g := newTestGitSV()
+
+	tests := []struct {
+		name    string
+		format  string
+		version string
+		want    string
+	}{
+		{"default", "", "1.0.0", "Version 1.0.0"},
+		{"custom", "Release %d.%d.%d", "1.1.0", "Release 1.1.0"},
+		{"pre-release", "", "1.2.0-rc.1", "Version 1.2.0-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Config.Tag.MessageFormat = tt.format
+
+			tag, err := g.Tag(*semver.MustParse(tt.version), true, true, "")
+			if err != nil {
+				t.Fatalf("GitSV.Tag() error = %v", err)
+			}
+
+			if tag != tt.version {
+				t.Errorf("GitSV.Tag() = %v, want %v", tag, tt.version)
+			}
+
+			if got := strings.TrimSpace(git(t, "tag", "-l", "--format=%(contents:subject)", tag)); got != tt.want {
+				t.Errorf("GitSV.Tag() message = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_LoadRoot(t *testing.T) {
+	root := initTestRepo(t)
+	chdir(t, t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join(root, ".gitsv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, filepath.Join(root, ".gitsv", "config.yml"), "tag:\n  pattern: v%d.%d.%d\n")
+
+	g := New()
+	g.Settings.Root = root
+	g = g.Load()
+
+	if got := *g.Config.Tag.Pattern; got != "v%d.%d.%d" {
+		t.Errorf("GitSV.Load() Tag.Pattern = %v, want %v", got, "v%d.%d.%d")
+	}
+
+	if got, want := g.Path("CHANGELOG.md"), filepath.Join(root, "CHANGELOG.md"); got != want {
+		t.Errorf("GitSV.Path() = %v, want %v", got, want)
+	}
+
+	if got := g.Path("/tmp/CHANGELOG.md"); got != "/tmp/CHANGELOG.md" {
+		t.Errorf("GitSV.Path() = %v, want %v", got, "/tmp/CHANGELOG.md")
+	}
+
+	if got := New().Load(); *got.Config.Tag.Pattern != "%d.%d.%d" {
+		t.Errorf("GitSV.Load() Tag.Pattern = %v, want default pattern", *got.Config.Tag.Pattern)
+	}
+}
+
+func TestGitSV_Root(t *testing.T) {
+	root := initTestRepo(t)
+	chdir(t, t.TempDir())
+
+	git(t, "-C", root, "commit", "-q", "--allow-empty", "-m", "feat: first feature")
+	git(t, "-C", root, "tag", "1.0.0")
+	git(t, "-C", root, "commit", "-q", "--allow-empty", "-m", "fix: some fix")
+
+	g := newTestGitSV()
+	g.Settings.Root = root
+
+	if got := g.LastTag(); got != "1.0.0" {
+		t.Errorf("GitSV.LastTag() = %v, want %v", got, "1.0.0")
+	}
+
+	if got := g.Branch(); got != "main" {
+		t.Errorf("GitSV.Branch() = %v, want %v", got, "main")
+	}
+
+	tags, err := g.Tags()
+	if err != nil || len(tags) != 1 || tags[0].Name != "1.0.0" {
+		t.Errorf("GitSV.Tags() = %v, error %v, want [1.0.0]", tags, err)
+	}
+
+	commits, err := g.Log(NewLogRange(TagRange, "1.0.0", ""))
+	if err != nil {
+		t.Fatalf("GitSV.Log() error = %v", err)
+	}
+
+	if len(commits) != 1 || commits[0].Message.Type != "fix" {
+		t.Errorf("GitSV.Log() = %v, want single fix commit", commits)
+	}
+}
+
+func TestGitSV_LastPreReleaseTag(t *testing.T) {
+	newTestRepo(t)
+
+	gitCommit(t, "feat: something")
+
+	for _, tag := range []string{"1.2.0-rc.2", "1.2.0-rc.10", "1.2.0-beta.3", "1.2.0", "1.3.0-rc.1"} {
+		git(t, "tag", tag)
+	}
+
+	g := newTestGitSV()
+	exclude := true
+	g.Config.Tag.ExcludePrereleases = &exclude
+
+	tests := []struct {
+		version string
+		name    string
+		want    string
+	}{
+		{"1.2.0", "rc", "1.2.0-rc.10"},
+		{"1.2.0", "beta", "1.2.0-beta.3"},
+		{"1.3.0", "rc", "1.3.0-rc.1"},
+		{"1.4.0", "rc", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+"-"+tt.name, func(t *testing.T) {
+			got, err := g.LastPreReleaseTag(*semver.MustParse(tt.version), tt.name)
+			if err != nil {
+				t.Fatalf("GitSV.LastPreReleaseTag() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("GitSV.LastPreReleaseTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseLastTagByCommitterDate(t *testing.T) {
+	noSkip := func(string) bool { return false }
+
+	tests := []struct {
+		name  string
+		input string
+		skip  func(string) bool
+		want  string
+	}{
+		{"empty", "", noSkip, ""},
+		{"lightweight tags", "100##v1.0.0\n200##v1.1.0\n", noSkip, "v1.1.0"},
+		{"annotated tags", "#300#v1.0.0\n#200#v1.1.0\n", noSkip, "v1.0.0"},
+		{"equal committer dates", "100##v1.0.0-rc\n100##v1.0.0\n", noSkip, "v1.0.0-rc"},
+		{"invalid date", "abc##v1.0.0\n100##v0.9.0\n", noSkip, "v0.9.0"},
+		{"skip prereleases", "200##v1.1.0-rc.1\n100##v1.0.0\n", sv.IsPrereleaseVersion, "v1.0.0"},
+		{"tag name with separator", "100##v1.0.0\n200##release#2\n", noSkip, "release#2"},
+		{"missing fields", "100#v1.0.0\n", noSkip, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLastTagByCommitterDate(tt.input, tt.skip); got != tt.want {
+				t.Errorf("parseLastTagByCommitterDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_LastTagSortBy(t *testing.T) {
+	newTestRepo(t)
+
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-02T00:00:00Z")
+	gitCommit(t, "feat: first feature")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-03T00:00:00Z")
+	git(t, "tag", "-a", "-m", "1.0.0", "1.0.0")
+
+	// commit dated before the previous one but tagged afterwards
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	gitCommit(t, "fix: some fix")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-04T00:00:00Z")
+	git(t, "tag", "-a", "-m", "v1.0.0", "v1.0.0")
+
+	tests := []struct {
+		sortBy string
+		want   string
+	}{
+		{TagSortCreatorDate, "v1.0.0"},
+		{TagSortCommitterDate, "1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			g := newTestGitSV()
+			g.Config.Tag.SortBy = tt.sortBy
+
+			if got := g.LastTag(); got != tt.want {
+				t.Errorf("GitSV.LastTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_FetchRemoteTags(t *testing.T) {
+	newTestRepo(t)
+
+	remote := t.TempDir()
+	git(t, "init", "-q", "--bare", remote)
+	git(t, "remote", "add", "origin", remote)
+
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-02T00:00:00Z")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.2.0")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-03T00:00:00Z")
+	gitCommit(t, "feat: third feature")
+	git(t, "tag", "1.3.0-rc.1")
+	git(t, "push", "-q", "origin", "main", "1.2.0", "1.3.0-rc.1")
+	git(t, "tag", "-d", "1.2.0", "1.3.0-rc.1")
+
+	tests := []struct {
+		name    string
+		remote  bool
+		exclude bool
+		want    string
+	}{
+		{"local tags only", false, false, "1.0.0"},
+		{"remote tags", true, true, "1.2.0"},
+		{"remote prerelease tags", true, false, "1.3.0-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV()
+			g.Config.Tag.RemoteTags = &tt.remote
+			g.Config.Tag.ExcludePrereleases = &tt.exclude
+
+			if err := g.FetchRemoteTags(); err != nil {
+				t.Fatalf("GitSV.FetchRemoteTags() error = %v", err)
+			}
+
+			if got := g.LastTag(); got != tt.want {
+				t.Errorf("GitSV.LastTag() = %v, want %v", got, tt.want)
+			}
+
+			tags, err := g.Tags()
+			if err != nil || len(tags) == 0 || tags[len(tags)-1].Name != tt.want {
+				t.Errorf("GitSV.Tags() = %v, error %v, want last tag %s", tags, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tagPatternRegex(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		tag     string
+		want    bool
+	}{
+		{"bare version", "%d.%d.%d", "1.2.3", true},
+		{"prefixed tag on bare pattern", "%d.%d.%d", "v1.2.3", false},
+		{"prefixed version", "v%d.%d.%d", "v1.2.3", true},
+		{"bare tag on prefixed pattern", "v%d.%d.%d", "1.2.3", false},
+		{"prerelease", "v%d.%d.%d", "v1.2.3-rc.1", true},
+		{"dot is not a wildcard", "%d.%d.%d", "1x2x3", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagPatternRegex(tt.pattern).MatchString(tt.tag); got != tt.want {
+				t.Errorf("tagPatternRegex(%q).MatchString(%q) = %v, want %v", tt.pattern, tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_TagDiagnostics(t *testing.T) {
+	newTestRepo(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.1.0")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{"matching tags", "%d.%d.%d", ""},
+		{"mismatched tags", "v%d.%d.%d", "tags do not match tag pattern: v%d.%d.%d in [1.0.0, 1.1.0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV()
+			g.Config.Tag.Pattern = &tt.pattern
+
+			got := g.TagDiagnostics()
+			if tt.want == "" {
+				if len(got) != 0 {
+					t.Errorf("GitSV.TagDiagnostics() = %v, want none", got)
+				}
+
+				return
+			}
+
+			if len(got) != 1 || !errors.Is(got[0], errTagMismatch) || got[0].Error() != tt.want {
+				t.Errorf("GitSV.TagDiagnostics() = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_ExcludePrereleases(t *testing.T) {
+	newTestRepo(t)
+
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "-a", "-m", "1.0.0", "1.0.0")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-02T00:00:00Z")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "-a", "-m", "1.1.0-rc.1", "1.1.0-rc.1")
+
+	tests := []struct {
+		name     string
+		exclude  bool
+		sortBy   string
+		wantLast string
+		wantTags []string
+	}{
+		{"include prereleases", false, TagSortCreatorDate, "1.1.0-rc.1", []string{"1.0.0", "1.1.0-rc.1"}},
+		{"exclude prereleases", true, TagSortCreatorDate, "1.0.0", []string{"1.0.0"}},
+		{"exclude prereleases by committer date", true, TagSortCommitterDate, "1.0.0", []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV()
+			g.Config.Tag.ExcludePrereleases = &tt.exclude
+			g.Config.Tag.SortBy = tt.sortBy
+
+			if got := g.LastTag(); got != tt.wantLast {
+				t.Errorf("GitSV.LastTag() = %v, want %v", got, tt.wantLast)
+			}
+
+			tags, err := g.Tags()
+			if err != nil {
+				t.Fatalf("GitSV.Tags() error = %v", err)
+			}
+
+			var names []string
+			for _, tag := range tags {
+				names = append(names, tag.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.wantTags) {
+				t.Errorf("GitSV.Tags() = %v, want %v", names, tt.wantTags)
+			}
+		})
+	}
+
+	g := newTestGitSV()
+	g.Settings.ExcludePrereleases = true
+
+	if got := g.LastTag(); got != "1.0.0" {
+		t.Errorf("GitSV.LastTag() with settings = %v, want %v", got, "1.0.0")
+	}
+}
+
+func TestGitSV_LatestReleaseRange(t *testing.T) {
+	newTestRepo(t)
+
+	g := newTestGitSV()
+
+	if _, _, err := g.LatestReleaseRange(); !errors.Is(err, errNoTags) {
+		t.Errorf("GitSV.LatestReleaseRange() error = %v, want %v", err, errNoTags)
+	}
+
+	tags := []string{"1.0.0", "1.1.0", "2.0.0"}
+	want := [][2]string{{"", "1.0.0"}, {"1.0.0", "1.1.0"}, {"1.1.0", "2.0.0"}}
+
+	for i, tag := range tags {
+		t.Setenv("GIT_COMMITTER_DATE", fmt.Sprintf("2020-01-0%dT00:00:00Z", i+1))
+		gitCommit(t, "feat: feature "+tag)
+		git(t, "tag", "-a", "-m", tag, tag)
+
+		prev, current, err := g.LatestReleaseRange()
+		if err != nil {
+			t.Fatalf("GitSV.LatestReleaseRange() error = %v", err)
+		}
+
+		if prev != want[i][0] || current != want[i][1] {
+			t.Errorf("GitSV.LatestReleaseRange() = (%v, %v), want (%v, %v)", prev, current, want[i][0], want[i][1])
+		}
+	}
+}
+
+func Test_parseNumstatOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  sv.CommitStats
+	}{
+		{"empty", "", sv.CommitStats{}},
+		{"text files", "3\t1\ta.txt\n2\t0\tb.txt\n", sv.CommitStats{FilesChanged: 2, Insertions: 5, Deletions: 1}},
+		{"binary file", "-\t-\timage.png\n1\t1\ta.txt\n", sv.CommitStats{FilesChanged: 2, Insertions: 1, Deletions: 1}},
+		{
+			"file in several commits", "3\t0\ta.txt\n\n1\t1\ta.txt\n",
+			sv.CommitStats{FilesChanged: 1, Insertions: 4, Deletions: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNumstatOutput(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNumstatOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_CommitStats(t *testing.T) {
+	newTestRepo(t)
+
+	writeFile(t, "a.txt", "one\ntwo\nthree\n")
+	git(t, "add", "a.txt")
+	gitCommit(t, "feat: add file")
+
+	writeFile(t, "a.txt", "one\n2\nthree\nfour\n")
+	writeFile(t, "b.txt", "new\n")
+	git(t, "add", "a.txt", "b.txt")
+	gitCommit(t, "fix: change files")
+
+	g := newTestGitSV()
+
+	commits, err := g.Log(NewLogRange(HashRange, "", ""))
+	if err != nil || len(commits) != 2 {
+		t.Fatalf("GitSV.Log() = %v, error %v", commits, err)
+	}
+
+	tests := []struct {
+		hash string
+		want sv.CommitStats
+	}{
+		{commits[0].Hash, sv.CommitStats{FilesChanged: 2, Insertions: 3, Deletions: 1}},
+		{commits[1].Hash, sv.CommitStats{FilesChanged: 1, Insertions: 3, Deletions: 0}},
+	}
+	for _, tt := range tests {
+		got, err := g.CommitStats(tt.hash)
+		if err != nil {
+			t.Fatalf("GitSV.CommitStats() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GitSV.CommitStats(%s) = %v, want %v", tt.hash, got, tt.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestGitSV() GitSV {
+	cfg := GetDefault()
+
+	return GitSV{
+		Settings:         &Settings{},
+		Config:           cfg,
+		MessageProcessor: sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+	}
+}
+
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := initTestRepo(t)
+	chdir(t, dir)
+
+	return dir
+}
+
+func initTestRepo(t testing.TB) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "author")
+	t.Setenv("GIT_AUTHOR_EMAIL", "author@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "committer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "committer@example.com")
+
+	git(t, "-C", dir, "init", "-q", "-b", "main")
+
+	return dir
+}
+
+func gitCommit(t testing.TB, message string) {
+	t.Helper()
+
+	git(t, "commit", "-q", "--allow-empty", "-m", message)
+}
+
+func git(t testing.TB, args ...string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", args...).CombinedOutput() //nolint:gosec
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+
+	return string(out)
+}
+
+func date(input string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05 -0700", input)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func TestGitSV_EmptyRepository(t *testing.T) {
+	newTestRepo(t)
+
+	g := newTestGitSV()
+
+	if g.HasCommits() {
+		t.Errorf("GitSV.HasCommits() = true, want false")
+	}
+
+	commits, err := g.Log(NewLogRange(TagRange, "", "HEAD"))
+	if err != nil || len(commits) != 0 {
+		t.Errorf("GitSV.Log() = %v, error %v, want empty", commits, err)
+	}
+
+	if _, err := g.Tag(*semver.MustParse("1.0.0"), false, true, ""); !errors.Is(err, errNoCommits) {
+		t.Errorf("GitSV.Tag() error = %v, want %v", err, errNoCommits)
+	}
+
+	gitCommit(t, "feat: first feature")
+
+	if !g.HasCommits() {
+		t.Errorf("GitSV.HasCommits() = false, want true")
+	}
+}
+
+func TestGitSV_FileVersion(t *testing.T) {
+	dir := initTestRepo(t)
+	writeFile(t, filepath.Join(dir, "VERSION"), " 1.2.3\n")
+	writeFile(t, filepath.Join(dir, "package.json"), "{\"engines\": {\"version\": \">=18\"}, \"version\": \"2.0.1\"}\n")
+	writeFile(t, filepath.Join(dir, "empty.json"), "{\"name\": \"pkg\"}\n")
+
+	tests := []struct {
+		name    string
+		file    string
+		want    string
+		wantErr bool
+	}{
+		{"not configured", "", "", false},
+		{"relative to root", "VERSION", "1.2.3", false},
+		{"absolute", filepath.Join(dir, "VERSION"), "1.2.3", false},
+		{"missing", "MISSING", "", true},
+		{"json", "package.json", "2.0.1", false},
+		{"json without version", "empty.json", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV()
+			g.Settings.Root = dir
+			g.Config.Tag.VersionFile = tt.file
+
+			got, err := g.FileVersion()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GitSV.FileVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("GitSV.FileVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_Commit(t *testing.T) {
+	newTestRepo(t)
+
+	g := newTestGitSV()
+
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		footer string
+	}{
+		{"header only", "feat: something", "", ""},
+		{"header and footer", "feat: something", "", "jira: JIRA-123"},
+		{"full message", "fix: other", "body line 1\nbody line 2\n", "BREAKING CHANGE: breaks\njira: JIRA-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeFile(t, "file.txt", tt.name)
+			git(t, "add", "file.txt")
+
+			if err := g.Commit(tt.header, tt.body, tt.footer, false); err != nil {
+				t.Fatalf("GitSV.Commit() error = %v", err)
+			}
+
+			raw := git(t, "cat-file", "commit", "HEAD")
+			got := raw[strings.Index(raw, "\n\n")+2:]
+
+			if want := sv.JoinMessage(tt.header, tt.body, tt.footer) + "\n"; got != want {
+				t.Errorf("GitSV.Commit() message = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGitSV_CommitAllowEmpty(t *testing.T) {
+	newTestRepo(t)
+	gitCommit(t, "feat: first feature")
+
+	g := newTestGitSV()
+
+	if err := g.Commit("chore: release point", "", "", false); err == nil {
+		t.Errorf("GitSV.Commit() without changes error = nil, want error")
+	}
+
+	if err := g.Commit("chore: release point", "", "", true); err != nil {
+		t.Fatalf("GitSV.Commit() with allow empty error = %v", err)
+	}
+
+	if got := strings.TrimSpace(git(t, "log", "-1", "--format=%s")); got != "chore: release point" {
+		t.Errorf("GitSV.Commit() subject = %s, want %s", got, "chore: release point")
+	}
+
+	if got := strings.TrimSpace(git(t, "rev-list", "--count", "HEAD")); got != "2" {
+		t.Errorf("GitSV.Commit() commits = %s, want 2", got)
+	}
+}
+
+func TestGitSV_LogIgnoreScopes(t *testing.T) {
+	newTestRepo(t)
+
+	gitCommit(t, "feat: first feature")
+	gitCommit(t, "chore(deps): bump lib")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "chore(deps): bump other lib")
+	gitCommit(t, "fix(api): first fix")
+
+	g := newTestGitSV()
+	g.Config.IgnoreScopes = []string{"deps"}
+
+	descriptions := func(commits []sv.CommitLog) []string {
+		var result []string
+		for _, commit := range commits {
+			result = append(result, commit.Message.Description)
+		}
+
+		return result
+	}
+
+	commits, err := g.Log(NewLogRange(TagRange, "", ""))
+	if err != nil {
+		t.Fatalf("GitSV.Log() error = %v", err)
+	}
+
+	if got, want := descriptions(commits), []string{"first fix", "first feature"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GitSV.Log() = %v, want %v", got, want)
+	}
+
+	byTag, err := g.LogByTag([]Tag{{Name: "1.0.0"}})
+	if err != nil {
+		t.Fatalf("GitSV.LogByTag() error = %v", err)
+	}
+
+	if got, want := descriptions(byTag["1.0.0"]), []string{"first feature"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GitSV.LogByTag() = %v, want %v", got, want)
+	}
+}
+
+func TestGitSV_LogByTag(t *testing.T) {
+	newTestRepo(t)
+
+	gitCommit(t, "feat: first feature")
+	gitCommit(t, "fix: first fix")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.1.0")
+	git(t, "tag", "1.1.0-alias")
+	gitCommit(t, "fix: second fix")
+	gitCommit(t, "feat: third feature")
+	git(t, "tag", "1.2.0")
+	gitCommit(t, "fix: unreleased fix")
+
+	g := newTestGitSV()
+	base := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	tags := []Tag{
+		{Name: "1.2.0", Date: base.Add(3 * time.Hour)},
+		{Name: "1.1.0-alias", Date: base.Add(2 * time.Hour)},
+		{Name: "1.1.0", Date: base.Add(time.Hour)},
+		{Name: "1.0.0", Date: base},
+	}
+
+	got, err := g.LogByTag(tags)
+	if err != nil {
+		t.Fatalf("GitSV.LogByTag() error = %v", err)
+	}
+
+	for i, tag := range tags {
+		previous := ""
+		if i+1 < len(tags) {
+			previous = tags[i+1].Name
+		}
+
+		want, err := g.Log(NewLogRange(TagRange, previous, tag.Name))
+		if err != nil {
+			t.Fatalf("GitSV.Log() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got[tag.Name], want) {
+			t.Errorf("GitSV.LogByTag()[%s] = %v, want %v", tag.Name, got[tag.Name], want)
+		}
+	}
+
+	if len(got) != 3 {
+		t.Errorf("GitSV.LogByTag() = %v, want 3 tag ranges", got)
+	}
+}
+
+func TestGitSV_LogByTagMergedBranch(t *testing.T) {
+	newTestRepo(t)
+
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-01T00:00:00Z")
+	gitCommit(t, "feat: first feature")
+	git(t, "checkout", "-q", "-b", "feature")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-02T00:00:00Z")
+	gitCommit(t, "feat: branch feature")
+	git(t, "checkout", "-q", "main")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-03T00:00:00Z")
+	gitCommit(t, "fix: first fix")
+	git(t, "tag", "1.0.0")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-01-04T00:00:00Z")
+	git(t, "merge", "-q", "--no-ff", "-m", "chore: merge feature", "feature")
+	git(t, "tag", "1.1.0")
+
+	g := newTestGitSV()
+	tags := []Tag{{Name: "1.1.0"}, {Name: "1.0.0"}}
+
+	got, err := g.LogByTag(tags)
+	if err != nil {
+		t.Fatalf("GitSV.LogByTag() error = %v", err)
+	}
+
+	descriptions := func(commits []sv.CommitLog) []string {
+		result := make([]string, 0, len(commits))
+		for _, commit := range commits {
+			result = append(result, commit.Message.Description)
+		}
+
+		return result
+	}
+
+	for _, tt := range []struct {
+		tag      string
+		previous string
+		want     []string
+	}{
+		{"1.1.0", "1.0.0", []string{"merge feature", "branch feature"}},
+		{"1.0.0", "", []string{"first fix", "first feature"}},
+	} {
+		if got := descriptions(got[tt.tag]); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GitSV.LogByTag()[%s] = %v, want %v", tt.tag, got, tt.want)
+		}
+
+		want, err := g.Log(NewLogRange(TagRange, tt.previous, tt.tag))
+		if err != nil {
+			t.Fatalf("GitSV.Log() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got[tt.tag], want) {
+			t.Errorf("GitSV.LogByTag()[%s] = %v, want %v", tt.tag, got[tt.tag], want)
+		}
+	}
+}
+
+func BenchmarkGitSV_LogByTag(b *testing.B) {
+	g, tags := newBenchmarkRepo(b)
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := g.LogByTag(tags); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGitSV_LogPerTag(b *testing.B) {
+	g, tags := newBenchmarkRepo(b)
+
+	b.ResetTimer()
+
+	for range b.N {
+		for i, tag := range tags {
+			previous := ""
+			if i+1 < len(tags) {
+				previous = tags[i+1].Name
+			}
+
+			if _, err := g.Log(NewLogRange(TagRange, previous, tag.Name)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// newBenchmarkRepo create a repository with 50 tags of 10 commits each, tags are sorted newest first.
+func newBenchmarkRepo(b *testing.B) (GitSV, []Tag) {
+	b.Helper()
+
+	dir := initTestRepo(b)
+	base := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	var tags []Tag
+
+	for i := range 50 {
+		for j := range 10 {
+			git(b, "-C", dir, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("feat: feature %d.%d", i, j))
+		}
+
+		name := fmt.Sprintf("%d.0.0", i)
+		git(b, "-C", dir, "tag", name)
+		tags = append([]Tag{{Name: name, Date: base.Add(time.Duration(i) * time.Hour)}}, tags...)
+	}
+
+	g := newTestGitSV()
+	g.Settings.Root = dir
+
+	return g, tags
+}
+
+func TestGitSV_LogAuthor(t *testing.T) {
+	newTestRepo(t)
+
+	t.Setenv("GIT_COMMITTER_NAME", "Release Bot")
+	t.Setenv("GIT_COMMITTER_EMAIL", "bot@example.com")
+	gitCommit(t, "feat: committed by someone else")
+
+	commits, err := newTestGitSV().Log(NewLogRange(HashRange, "", ""))
+	if err != nil {
+		t.Fatalf("GitSV.Log() error = %v", err)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("GitSV.Log() commits = %d, want 1", len(commits))
+	}
+
+	if got := [2]string{commits[0].AuthorName, commits[0].AuthorEmail}; got != [2]string{"author", "author@example.com"} {
+		t.Errorf("GitSV.Log() author = %v, want [author author@example.com]", got)
+	}
+}
+
+func TestGitSV_LogMailmap(t *testing.T) {
+	newTestRepo(t)
+
+	writeFile(t, ".mailmap", "Jane Doe <jane@example.com> <jane.old@example.com>\n"+
+		"Jane Doe <jane@example.com> J. Doe <jdoe@example.com>\n"+
+		"Release Bot <bot@example.com> <committer@example.com>\n")
+
+	// commits are made by a mapped committer, only the author identity must be listed.
+	t.Setenv("GIT_COMMITTER_NAME", "committer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "committer@example.com")
+
+	for _, identity := range [][2]string{
+		{"Jane Old", "jane.old@example.com"},
+		{"J. Doe", "jdoe@example.com"},
+		{"John Smith", "john@example.com"},
+	} {
+		t.Setenv("GIT_AUTHOR_NAME", identity[0])
+		t.Setenv("GIT_AUTHOR_EMAIL", identity[1])
+		gitCommit(t, "feat: commit by "+identity[0])
+	}
+
+	commits, err := newTestGitSV().Log(NewLogRange(HashRange, "", ""))
+	if err != nil {
+		t.Fatalf("GitSV.Log() error = %v", err)
+	}
+
+	want := [][2]string{
+		{"John Smith", "john@example.com"},
+		{"Jane Doe", "jane@example.com"},
+		{"Jane Doe", "jane@example.com"},
+	}
+
+	got := make([][2]string, len(commits))
+	for i, commit := range commits {
+		got[i] = [2]string{commit.AuthorName, commit.AuthorEmail}
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GitSV.Log() authors = %v, want %v", got, want)
+	}
+}
+
+func TestGitSV_RangeStats(t *testing.T) {
+	newTestRepo(t)
+
+	writeFile(t, "a.txt", "one\n")
+	git(t, "add", "a.txt")
+	gitCommit(t, "feat: add file")
+	git(t, "tag", "1.0.0")
+
+	writeFile(t, "a.txt", "one\ntwo\nthree\n")
+	writeFile(t, "b.txt", "new\n")
+	git(t, "add", "a.txt", "b.txt")
+	gitCommit(t, "feat: change files")
+
+	writeFile(t, "a.txt", "one\n2\nthree\n")
+	git(t, "add", "a.txt")
+	gitCommit(t, "fix: change file again")
+
+	g := newTestGitSV()
+
+	tests := []struct {
+		name string
+		from string
+		want sv.CommitStats
+	}{
+		{"since tag", "1.0.0", sv.CommitStats{FilesChanged: 2, Insertions: 4, Deletions: 1}},
+		{"whole history", "", sv.CommitStats{FilesChanged: 2, Insertions: 5, Deletions: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.RangeStats(tt.from, "HEAD")
+			if err != nil {
+				t.Fatalf("GitSV.RangeStats() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GitSV.RangeStats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSV_TagsMalformedAnnotatedTag(t *testing.T) {
+	newTestRepo(t)
+
+	t.Setenv("GIT_COMMITTER_DATE", "2020-05-01 18:00:00 -0300")
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-05-02 18:00:00 -0300")
+	gitCommit(t, "feat: second feature")
+
+	// annotated tag without tagger, git tag refuses to create it
+	object := fmt.Sprintf(
+		"object %s\ntype commit\ntag 1.1.0\n\nVersion 1.1.0\n",
+		strings.TrimSpace(git(t, "rev-parse", "HEAD")),
+	)
+
+	cmd := exec.Command("git", "hash-object", "-t", "tag", "--literally", "-w", "--stdin")
+	cmd.Stdin = strings.NewReader(object)
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git hash-object error = %v", err)
+	}
+
+	git(t, "update-ref", "refs/tags/1.1.0", strings.TrimSpace(string(out)))
+
+	g := newTestGitSV()
+
+	tags, err := g.Tags()
+	if err != nil {
+		t.Fatalf("GitSV.Tags() error = %v", err)
+	}
+
+	if len(tags) != 2 || tags[1].Name != "1.1.0" {
+		t.Fatalf("GitSV.Tags() = %v, want 1.1.0 last", tags)
+	}
+
+	if want := date("2020-05-02 18:00:00 -0300"); !tags[1].Date.Equal(want) {
+		t.Errorf("GitSV.Tags() date = %v, want %v", tags[1].Date, want)
+	}
+
+	if got := g.LastTag(); got != "1.1.0" {
+		t.Errorf("GitSV.LastTag() = %v, want %v", got, "1.1.0")
+	}
 }
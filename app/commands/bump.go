@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	defaultVersionFile  = "VERSION"
+	releaseCommitHeader = "chore(release): %s"
+)
+
+func BumpFlags(settings *app.BumpSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "file",
+			Usage:       "file updated with the next version (default: config tag.version-file or VERSION)",
+			Destination: &settings.File,
+		},
+		&cli.BoolFlag{
+			Name:        "commit",
+			Usage:       "commit the updated file with a 'chore(release): <version>' message",
+			Destination: &settings.Commit,
+		},
+	}
+}
+
+func BumpHandler(g app.GitSV, settings *app.BumpSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		lastTag := g.LastTag()
+
+		nextVer, updated, _, _, err := getNextVersionInfo(g, g.CommitProcessor, lastTag, "")
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			log.Info().Msgf("nothing to do: current version %s unchanged", nextVer)
+
+			return nil
+		}
+
+		version := versionString(nextVer)
+		file := str(settings.File, str(g.Config.Tag.VersionFile, defaultVersionFile))
+
+		original, readErr := os.ReadFile(g.Path(file))
+
+		if err := g.WriteVersionFile(file, version); err != nil {
+			return err
+		}
+
+		if settings.Commit {
+			if err := commitVersionFile(g, file, version); err != nil {
+				restoreVersionFile(g, file, original, readErr == nil)
+
+				return err
+			}
+		}
+
+		fmt.Fprintln(c.App.Writer, version)
+
+		return nil
+	}
+}
+
+// commitVersionFile commit only the version file, other staged changes are not included.
+func commitVersionFile(g app.GitSV, file, version string) error {
+	if err := g.Add(file); err != nil {
+		return fmt.Errorf("error adding version file: %w", err)
+	}
+
+	if err := g.CommitPaths(fmt.Sprintf(releaseCommitHeader, version), file); err != nil {
+		return fmt.Errorf("error committing version file: %w", err)
+	}
+
+	return nil
+}
+
+// restoreVersionFile restore the original content of the version file after a failed commit, the file is
+// removed if it did not exist.
+func restoreVersionFile(g app.GitSV, file string, original []byte, existed bool) {
+	var err error
+
+	if existed {
+		err = os.WriteFile(g.Path(file), original, laxFilePerm)
+	} else {
+		err = os.Remove(g.Path(file))
+	}
+
+	if err != nil {
+		log.Warn().Err(err).Msgf("could not restore version file %s", file)
+	}
+
+	if err := g.Unstage(file); err != nil {
+		log.Warn().Err(err).Msgf("could not unstage version file %s", file)
+	}
+}
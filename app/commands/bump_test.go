@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/urfave/cli/v2"
+)
+
+func TestBumpHandler(t *testing.T) {
+	packageJSON := "{\n  \"name\": \"pkg\",\n  \"version\": \"%s\",\n  \"private\": true\n}\n"
+
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		args        []string
+		want        string
+		wantMessage string
+	}{
+		{"version file", "VERSION", "", []string{}, "1.1.0\n", "feat: second feature"},
+		{"version file with commit", "VERSION", "1.0.0\n", []string{"--commit"}, "1.1.0\n", "chore(release): 1.1.0"},
+		{
+			"package.json", "package.json", strings.Replace(packageJSON, "%s", "1.0.0", 1),
+			[]string{"--file", "package.json", "--commit"},
+			strings.Replace(packageJSON, "%s", "1.1.0", 1), "chore(release): 1.1.0",
+		},
+		{
+			"package.json with nested version", "package.json",
+			"{\n  \"engines\": {\"version\": \">=18\"},\n  \"version\": \"1.0.0\"\n}\n",
+			[]string{"--file", "package.json"},
+			"{\n  \"engines\": {\"version\": \">=18\"},\n  \"version\": \"1.1.0\"\n}\n", "feat: second feature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV(t)
+
+			if tt.content != "" {
+				if err := os.WriteFile(tt.file, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				git(t, "add", tt.file)
+			}
+
+			gitCommit(t, "feat: first feature")
+			git(t, "tag", "1.0.0")
+			gitCommit(t, "feat: second feature")
+
+			settings := &app.BumpSettings{}
+			if got := runCommand(t, BumpFlags(settings), BumpHandler(g, settings), tt.args...); got != "1.1.0\n" {
+				t.Errorf("BumpHandler() = %q, want %q", got, "1.1.0\n")
+			}
+
+			content, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatalf("BumpHandler() version file not written: %v", err)
+			}
+
+			if string(content) != tt.want {
+				t.Errorf("BumpHandler() version file = %q, want %q", content, tt.want)
+			}
+
+			if got := strings.TrimSpace(git(t, "log", "-1", "--format=%s")); got != tt.wantMessage {
+				t.Errorf("BumpHandler() last commit = %q, want %q", got, tt.wantMessage)
+			}
+
+			if settings.Commit && strings.TrimSpace(git(t, "status", "--porcelain")) != "" {
+				t.Errorf("BumpHandler() version file not committed: %s", git(t, "status", "--porcelain"))
+			}
+		})
+	}
+}
+
+func TestBumpHandlerNothingToDo(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+
+	settings := &app.BumpSettings{}
+	if got := runCommand(t, BumpFlags(settings), BumpHandler(g, settings), "--commit"); got != "" {
+		t.Errorf("BumpHandler() = %q, want no version", got)
+	}
+
+	if _, err := os.Stat("VERSION"); !os.IsNotExist(err) {
+		t.Errorf("BumpHandler() version file error = %v, want not written", err)
+	}
+}
+
+func TestBumpHandlerJSONWithoutVersion(t *testing.T) {
+	g := newTestGitSV(t)
+
+	if err := os.WriteFile("package.json", []byte("{\"name\": \"pkg\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	gitCommit(t, "feat: first feature")
+
+	settings := &app.BumpSettings{}
+
+	cliApp := &cli.App{Flags: BumpFlags(settings), Action: BumpHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--file", "package.json"}); err == nil {
+		t.Errorf("BumpHandler() error = %v, want no version field error", err)
+	}
+}
+
+func TestBumpHandlerCommitOnlyVersionFile(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+
+	if err := os.WriteFile("staged.txt", []byte("staged\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	git(t, "add", "staged.txt")
+
+	settings := &app.BumpSettings{}
+	runCommand(t, BumpFlags(settings), BumpHandler(g, settings), "--commit")
+
+	if got := strings.TrimSpace(git(t, "show", "--name-only", "--format=", "HEAD")); got != "VERSION" {
+		t.Errorf("BumpHandler() committed files = %q, want VERSION", got)
+	}
+
+	if got := strings.TrimSpace(git(t, "status", "--porcelain")); got != "A  staged.txt" {
+		t.Errorf("BumpHandler() status = %q, want staged.txt still staged", got)
+	}
+}
+
+func TestBumpHandlerCommitFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"existing version file", "1.0.0\n"},
+		{"new version file", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV(t)
+
+			if tt.content != "" {
+				if err := os.WriteFile("VERSION", []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				git(t, "add", "VERSION")
+			}
+
+			gitCommit(t, "feat: first feature")
+			git(t, "tag", "1.0.0")
+			gitCommit(t, "feat: second feature")
+
+			if err := os.WriteFile(".git/hooks/pre-commit", []byte("#!/bin/sh\nexit 1\n"), 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			settings := &app.BumpSettings{}
+
+			cliApp := &cli.App{Flags: BumpFlags(settings), Action: BumpHandler(g, settings)}
+			if err := cliApp.Run([]string{"git-sv", "--commit"}); err == nil {
+				t.Fatalf("BumpHandler() error = %v, want commit error", err)
+			}
+
+			content, err := os.ReadFile("VERSION")
+			if tt.content == "" && !os.IsNotExist(err) {
+				t.Errorf("BumpHandler() version file = %q, error %v, want removed", content, err)
+			}
+
+			if tt.content != "" && string(content) != tt.content {
+				t.Errorf("BumpHandler() version file = %q, want %q", content, tt.content)
+			}
+
+			if got := strings.TrimSpace(git(t, "status", "--porcelain")); got != "" {
+				t.Errorf("BumpHandler() status = %q, want clean", got)
+			}
+		})
+	}
+}
@@ -1,15 +1,38 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/thegeeklab/git-sv/app"
 	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	splitDirPerm      = 0o755
+	changelogJSONDate = "2006-01-02"
+)
+
+var errCanNotSplitJSON = errors.New("cannot define split-dir flag with json format")
+
+type releaseOutput struct {
+	Version  string          `json:"version,omitempty"`
+	Tag      string          `json:"tag,omitempty"`
+	Date     string          `json:"date,omitempty"`
+	Bump     string          `json:"bump,omitempty"`
+	Sections []sectionOutput `json:"sections"`
+	Authors  []string        `json:"authors"`
+}
+
 func ChangelogFlags(settings *app.ChangelogSettings) []cli.Flag {
 	return []cli.Flag{
 		&cli.IntFlag{
@@ -40,12 +63,56 @@ func ChangelogFlags(settings *app.ChangelogSettings) []cli.Flag {
 			Usage:       "output file name. Omit to use standard output.",
 			Destination: &settings.Out,
 		},
+		&cli.BoolFlag{
+			Name:        "collapse-patches",
+			Usage:       "collapse consecutive patch releases of a minor version into a single release",
+			Destination: &settings.CollapsePatches,
+		},
+		&cli.BoolFlag{
+			Name:        "ascending",
+			Aliases:     []string{"reverse"},
+			Usage:       "list releases from oldest to newest, size still selects the newest releases",
+			Destination: &settings.Ascending,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, use: md or json (default: config default-output-format)",
+			Destination: &settings.Format,
+		},
+		&cli.StringFlag{
+			Name:        "split-dir",
+			Usage:       "write release notes of each release to <dir>/<version>.md, e.g. 1.2.0.md for tag v1.2.0",
+			Destination: &settings.SplitDir,
+		},
+		&cli.StringFlag{
+			Name:        "with-footer",
+			Usage:       "only include commits with the given footer key, e.g. Security-Review",
+			Destination: &settings.WithFooter,
+		},
+		&cli.BoolFlag{
+			Name:        "warn-dropped",
+			Usage:       "log a warning for each commit not rendered because its type is not mapped to any section",
+			Destination: &settings.WarnDropped,
+		},
 	}
 }
 
 //nolint:gocognit
 func ChangelogHandler(g app.GitSV, settings *app.ChangelogSettings) cli.ActionFunc {
-	return func(_ *cli.Context) error {
+	return func(c *cli.Context) error {
+		format := changelogFormat(settings.Format, g.Config)
+
+		switch format {
+		case formatter.FormatMarkdown:
+		case app.OutputFormatJSON:
+			if settings.SplitDir != "" {
+				return errCanNotSplitJSON
+			}
+		default:
+			return fmt.Errorf("%w: %s, expected: %s or %s", errUnknownOutputFormat, format,
+				formatter.FormatMarkdown, app.OutputFormatJSON)
+		}
+
 		tags, err := g.Tags()
 		if err != nil {
 			return err
@@ -55,16 +122,37 @@ func ChangelogHandler(g app.GitSV, settings *app.ChangelogSettings) cli.ActionFu
 			return tags[i].Date.After(tags[j].Date)
 		})
 
+		if settings.CollapsePatches {
+			tags = collapsePatchTags(tags)
+		}
+
 		var releaseNotes []sv.ReleaseNote
 
 		if settings.AddNext {
-			rnVersion, updated, date, commits, uerr := getNextVersionInfo(g, g.CommitProcessor)
+			rnVersion, updated, date, commits, uerr := getNextVersionInfo(g, g.CommitProcessor, "", "")
 			if uerr != nil {
 				return uerr
 			}
 
+			commits = filterCommitsByFooter(commits, settings.WithFooter)
+
 			if updated {
-				releaseNotes = append(releaseNotes, g.ReleasenotesProcessor.Create(rnVersion, "", date, commits))
+				if settings.WarnDropped {
+					warnDroppedCommits(g.Config.ReleaseNotes, commits)
+				}
+
+				releaseNote := g.ReleasenotesProcessor.Create(rnVersion, "", date, commits)
+				lastVer, _ := currentVersion(g, g.LastTag())
+				releaseNote.Bump = sv.BumpType(lastVer, rnVersion)
+				releaseNotes = append(releaseNotes, releaseNote)
+			}
+		}
+
+		// the full changelog is built from a single log walk instead of one walk per tag range
+		var tagCommits map[string][]sv.CommitLog
+		if settings.All {
+			if tagCommits, err = g.LogByTag(tags); err != nil {
+				return fmt.Errorf("error getting git log from tags: %w", err)
 			}
 		}
 
@@ -82,27 +170,52 @@ func ChangelogHandler(g app.GitSV, settings *app.ChangelogSettings) cli.ActionFu
 				continue
 			}
 
-			commits, err := g.Log(app.NewLogRange(app.TagRange, previousTag, tag.Name))
-			if err != nil {
-				return fmt.Errorf("error getting git log from tag: %s: %w", tag.Name, err)
+			commits := tagCommits[tag.Name]
+			if !settings.All {
+				if commits, err = g.Log(app.NewLogRange(app.TagRange, previousTag, tag.Name)); err != nil {
+					return fmt.Errorf("error getting git log from tag: %s: %w", tag.Name, err)
+				}
+			}
+
+			commits = filterCommitsByFooter(commits, settings.WithFooter)
+
+			if settings.WarnDropped {
+				warnDroppedCommits(g.Config.ReleaseNotes, commits)
 			}
 
 			currentVer, _ := sv.ToVersion(tag.Name)
-			releaseNotes = append(releaseNotes, g.ReleasenotesProcessor.Create(currentVer, tag.Name, tag.Date, commits))
+			previousVer, _ := sv.ToVersion(previousTag)
+			releaseNote := g.ReleasenotesProcessor.Create(currentVer, tag.Name, tag.Date, commits)
+			releaseNote.Bump = sv.BumpType(previousVer, currentVer)
+			releaseNotes = append(releaseNotes, releaseNote)
+		}
+
+		if settings.Ascending {
+			slices.Reverse(releaseNotes)
+		}
+
+		if settings.SplitDir != "" {
+			return writeSplitChangelog(g.OutputFormatter, g.Path(settings.SplitDir), releaseNotes)
+		}
+
+		var output []byte
+		if format == app.OutputFormatJSON {
+			output, err = json.Marshal(toReleaseOutputs(releaseNotes))
+		} else {
+			output, err = g.OutputFormatter.FormatChangelog(releaseNotes)
 		}
 
-		output, err := g.OutputFormatter.FormatChangelog(releaseNotes)
 		if err != nil {
 			return fmt.Errorf("could not format changelog: %w", err)
 		}
 
 		if settings.Out == "" {
-			os.Stdout.WriteString(fmt.Sprintf("%s\n", output))
+			fmt.Fprintf(c.App.Writer, "%s\n", output)
 
 			return nil
 		}
 
-		w, err := os.Create(settings.Out)
+		w, err := os.Create(g.Path(settings.Out))
 		if err != nil {
 			return fmt.Errorf("could not write changelog: %w", err)
 		}
@@ -115,3 +228,126 @@ func ChangelogHandler(g app.GitSV, settings *app.ChangelogSettings) cli.ActionFu
 		return nil
 	}
 }
+
+func toReleaseOutputs(releaseNotes []sv.ReleaseNote) []releaseOutput {
+	result := make([]releaseOutput, 0, len(releaseNotes))
+
+	for _, rn := range releaseNotes {
+		release := releaseOutput{
+			Tag:      rn.Tag,
+			Bump:     rn.Bump,
+			Sections: make([]sectionOutput, 0, len(rn.Sections)),
+			Authors:  make([]string, 0, len(rn.AuthorsNames)),
+		}
+
+		if rn.Version != nil {
+			release.Version = rn.Version.String()
+		}
+
+		if !rn.Date.IsZero() {
+			release.Date = rn.Date.Format(changelogJSONDate)
+		}
+
+		for _, section := range rn.Sections {
+			release.Sections = append(release.Sections, newSectionOutput(section))
+		}
+
+		for name := range rn.AuthorsNames {
+			release.Authors = append(release.Authors, name)
+		}
+
+		sort.Strings(release.Authors)
+
+		result = append(result, release)
+	}
+
+	return result
+}
+
+// changelogFormat return the format flag value or the configured default if the flag is empty, the text
+// default of the config is rendered as markdown.
+func changelogFormat(flag string, cfg *app.Config) string {
+	if flag != "" {
+		return flag
+	}
+
+	if cfg.DefaultOutputFormat == app.OutputFormatJSON {
+		return app.OutputFormatJSON
+	}
+
+	return formatter.FormatMarkdown
+}
+
+// filterCommitsByFooter keep only the commits with the footer key, all commits are kept if key is empty.
+func filterCommitsByFooter(commits []sv.CommitLog, key string) []sv.CommitLog {
+	if key == "" {
+		return commits
+	}
+
+	result := make([]sv.CommitLog, 0, len(commits))
+
+	for _, commit := range commits {
+		if commit.Message.HasFooter(key) {
+			result = append(result, commit)
+		}
+	}
+
+	return result
+}
+
+// collapsePatchTags keep only the newest tag of each run of consecutive tags sharing the same
+// major and minor version, tags must be sorted from newest to oldest.
+func collapsePatchTags(tags []app.Tag) []app.Tag {
+	result := make([]app.Tag, 0, len(tags))
+
+	var last *semver.Version
+
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag.Name)
+		if err != nil {
+			result = append(result, tag)
+			last = nil
+
+			continue
+		}
+
+		if last != nil && last.Major() == version.Major() && last.Minor() == version.Minor() {
+			continue
+		}
+
+		result = append(result, tag)
+		last = version
+	}
+
+	return result
+}
+
+func writeSplitChangelog(f formatter.OutputFormatter, dir string, releaseNotes []sv.ReleaseNote) error {
+	if err := os.MkdirAll(dir, splitDirPerm); err != nil {
+		return fmt.Errorf("could not create changelog directory: %w", err)
+	}
+
+	for _, rn := range releaseNotes {
+		output, err := f.FormatReleaseNote(rn)
+		if err != nil {
+			return fmt.Errorf("could not format release notes: %w", err)
+		}
+
+		filename := filepath.Join(dir, releaseNoteFilename(rn)+".md")
+		if err := os.WriteFile(filename, output, laxFilePerm); err != nil {
+			return fmt.Errorf("could not write release notes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseNoteFilename return the version of the release note without tag prefix, e.g. 1.0.0 for tag v1.0.0.
+// The tag is used for tags which are not a version, with / replaced by -.
+func releaseNoteFilename(rn sv.ReleaseNote) string {
+	if rn.Version != nil {
+		return rn.Version.String()
+	}
+
+	return strings.ReplaceAll(rn.Tag, "/", "-")
+}
@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/thegeeklab/git-sv/templates"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_writeSplitChangelog(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "changelog")
+	date := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	f := formatter.NewOutputFormatter(templates.New(""))
+
+	releaseNotes := []sv.ReleaseNote{
+		{Version: semver.MustParse("1.1.0"), Date: date},
+		{Version: semver.MustParse("1.0.0"), Tag: "v1.0.0", Date: date},
+		{Version: semver.MustParse("0.1.0"), Tag: "app/v0.1.0", Date: date},
+		{Tag: "release/latest", Date: date},
+	}
+
+	if err := writeSplitChangelog(f, dir, releaseNotes); err != nil {
+		t.Fatalf("writeSplitChangelog() error = %v", err)
+	}
+
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"1.1.0.md", "## v1.1.0 (2020-05-01)"},
+		{"1.0.0.md", "## v1.0.0 (2020-05-01)"},
+		{"0.1.0.md", "## v0.1.0 (2020-05-01)"},
+		{"release-latest.md", "## release/latest (2020-05-01)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(dir, tt.filename))
+			if err != nil {
+				t.Fatalf("missing release notes file: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("release notes file = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(releaseNotes) {
+		t.Errorf("writeSplitChangelog() created %d files, want %d", len(entries), len(releaseNotes))
+	}
+}
+
+func Test_collapsePatchTags(t *testing.T) {
+	toTags := func(names ...string) []app.Tag {
+		tags := make([]app.Tag, len(names))
+		for i, name := range names {
+			tags[i] = app.Tag{Name: name}
+		}
+
+		return tags
+	}
+
+	tests := []struct {
+		name  string
+		input []app.Tag
+		want  []app.Tag
+	}{
+		{"empty", toTags(), toTags()},
+		{
+			"patch releases under one minor",
+			toTags("1.2.3", "1.2.2", "1.2.1", "1.2.0", "1.1.0"),
+			toTags("1.2.3", "1.1.0"),
+		},
+		{
+			"multiple minor lines",
+			toTags("v2.0.1", "v2.0.0", "v1.1.1", "v1.1.0", "v1.0.2", "v1.0.1"),
+			toTags("v2.0.1", "v1.1.1", "v1.0.2"),
+		},
+		{"non semver tags", toTags("1.0.1", "latest", "1.0.0"), toTags("1.0.1", "latest", "1.0.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collapsePatchTags(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collapsePatchTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangelogHandlerEmptyRepository(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	settings := &app.ChangelogSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--add-next"}); err != nil {
+		t.Fatalf("ChangelogHandler() error = %v", err)
+	}
+
+	if got, want := out.String(), "# Changelog\n"; got != want {
+		t.Errorf("ChangelogHandler() = %q, want %q", got, want)
+	}
+}
+
+func TestChangelogHandlerAllSingleWalk(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first fix")
+	git(t, "checkout", "-q", "-b", "feature")
+	gitCommit(t, "feat: branch feature")
+	git(t, "checkout", "-q", "main")
+	gitCommit(t, "fix: second fix")
+	t.Setenv("GIT_COMMITTER_DATE", "2020-05-01T00:04:30Z")
+	git(t, "merge", "-q", "--no-ff", "-m", "chore: merge feature", "feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "feat: third feature")
+	git(t, "tag", "1.2.0")
+	gitCommit(t, "fix: unreleased fix")
+
+	run := func(args ...string) string {
+		t.Helper()
+
+		settings := &app.ChangelogSettings{}
+
+		var out bytes.Buffer
+
+		cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+		if err := cliApp.Run(append([]string{"git-sv"}, args...)); err != nil {
+			t.Fatalf("ChangelogHandler() error = %v", err)
+		}
+
+		return out.String()
+	}
+
+	got := run("--all")
+	if want := run("--size", "100"); got != want {
+		t.Errorf("ChangelogHandler() --all = %q, want %q", got, want)
+	}
+
+	if !strings.Contains(got, "branch feature") || strings.Contains(got, "unreleased fix") {
+		t.Errorf("ChangelogHandler() --all = %q, want released commits only", got)
+	}
+}
+
+func TestChangelogHandlerAscending(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first fix")
+	git(t, "tag", "1.0.1")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.1.0")
+
+	run := func(args ...string) string {
+		t.Helper()
+
+		settings := &app.ChangelogSettings{}
+
+		var out bytes.Buffer
+
+		cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+		if err := cliApp.Run(append([]string{"git-sv"}, args...)); err != nil {
+			t.Fatalf("ChangelogHandler() error = %v", err)
+		}
+
+		return out.String()
+	}
+
+	releases := func(output string) []string {
+		var result []string
+
+		for _, release := range strings.Split(strings.TrimPrefix(output, "# Changelog"), "---\n") {
+			if release = strings.TrimSpace(release); release != "" {
+				result = append(result, release)
+			}
+		}
+
+		return result
+	}
+
+	descending := releases(run())
+	ascending := releases(run("--ascending"))
+
+	slices.Reverse(descending)
+
+	if !reflect.DeepEqual(ascending, descending) {
+		t.Errorf("ChangelogHandler() --ascending = %q, want %q", ascending, descending)
+	}
+
+	if !strings.HasPrefix(ascending[0], "## v1.0.0") || !strings.Contains(ascending[0], "first feature") {
+		t.Errorf("ChangelogHandler() --ascending first release = %q, want v1.0.0 with first feature", ascending[0])
+	}
+}
+
+func TestChangelogHandlerWithFooter(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: reviewed feature\n\nSecurity-Review: alice")
+	gitCommit(t, "feat: unreviewed feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: unreviewed fix")
+	gitCommit(t, "fix: reviewed fix\n\nSome details.\n\nSecurity-Review: bob")
+
+	settings := &app.ChangelogSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--add-next", "--with-footer", "Security-Review"}); err != nil {
+		t.Fatalf("ChangelogHandler() error = %v", err)
+	}
+
+	got := out.String()
+
+	for _, want := range []string{"reviewed feature", "reviewed fix"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ChangelogHandler() = %s, want to contain %s", got, want)
+		}
+	}
+
+	for _, unwanted := range []string{"unreviewed feature", "unreviewed fix"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("ChangelogHandler() = %s, want not to contain %s", got, unwanted)
+		}
+	}
+}
+
+func TestChangelogHandlerJSON(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "feat!: breaking feature")
+	git(t, "tag", "2.0.0")
+	gitCommit(t, "fix: unreleased fix")
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantVersions []string
+	}{
+		{"all tags", []string{"--format", "json"}, []string{"2.0.0", "1.0.0"}},
+		{"size", []string{"--format", "json", "--size", "1"}, []string{"2.0.0"}},
+		{"add next", []string{"--format", "json", "--add-next"}, []string{"2.0.1", "2.0.0", "1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ChangelogSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+			if err := cliApp.Run(append([]string{"git-sv"}, tt.args...)); err != nil {
+				t.Fatalf("ChangelogHandler() error = %v", err)
+			}
+
+			var got []releaseOutput
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("ChangelogHandler() invalid json: %v: %s", err, out.String())
+			}
+
+			versions := make([]string, len(got))
+			for i, release := range got {
+				versions[i] = release.Version
+			}
+
+			if !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("ChangelogHandler() versions = %v, want %v", versions, tt.wantVersions)
+			}
+		})
+	}
+}
+
+func TestChangelogHandlerDefaultOutputFormat(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+
+	tests := []struct {
+		name          string
+		defaultFormat string
+		args          []string
+		wantJSON      bool
+	}{
+		{"text default", app.OutputFormatText, []string{}, false},
+		{"json default", app.OutputFormatJSON, []string{}, true},
+		{"flag overrides default", app.OutputFormatJSON, []string{"--format", "md"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := *g.Config
+			cfg.DefaultOutputFormat = tt.defaultFormat
+			g.Config = &cfg
+
+			settings := &app.ChangelogSettings{}
+			got := runCommand(t, ChangelogFlags(settings), ChangelogHandler(g, settings), tt.args...)
+
+			if isJSON := json.Valid([]byte(got)); isJSON != tt.wantJSON {
+				t.Errorf("ChangelogHandler() = %s, want json %v", got, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestChangelogHandlerJSONContent(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "feat!: breaking feature")
+	git(t, "tag", "1.0.0")
+
+	settings := &app.ChangelogSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--format", "json"}); err != nil {
+		t.Fatalf("ChangelogHandler() error = %v", err)
+	}
+
+	var got []releaseOutput
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("ChangelogHandler() invalid json: %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("ChangelogHandler() releases = %d, want 1", len(got))
+	}
+
+	release := got[0]
+	if release.Tag != "1.0.0" || release.Date != "2020-05-01" ||
+		!reflect.DeepEqual(release.Authors, []string{"author"}) {
+		t.Errorf("ChangelogHandler() release = %+v, want tag 1.0.0 of 2020-05-01 by author", release)
+	}
+
+	sections := make(map[string]sectionOutput)
+	for _, section := range release.Sections {
+		sections[section.Name] = section
+	}
+
+	if features := sections["Features"]; len(features.Commits) != 2 || features.Type != sv.ReleaseNotesSectionTypeCommits {
+		t.Errorf("ChangelogHandler() features = %+v, want 2 commits", features)
+	}
+
+	if fixes := sections["Bug Fixes"]; len(fixes.Commits) != 1 || fixes.Commits[0].Message.Description != "first fix" {
+		t.Errorf("ChangelogHandler() bug fixes = %+v, want first fix", fixes)
+	}
+
+	if breaking := sections["Breaking Changes"]; !reflect.DeepEqual(breaking.Messages, []string{"breaking feature"}) {
+		t.Errorf("ChangelogHandler() breaking changes = %+v, want breaking feature", breaking)
+	}
+}
+
+func TestChangelogHandlerJSONSplitDir(t *testing.T) {
+	g := newTestGitSV(t)
+	settings := &app.ChangelogSettings{}
+
+	cliApp := &cli.App{Flags: ChangelogFlags(settings), Action: ChangelogHandler(g, settings)}
+
+	err := cliApp.Run([]string{"git-sv", "--format", "json", "--split-dir", t.TempDir()})
+	if !errors.Is(err, errCanNotSplitJSON) {
+		t.Errorf("ChangelogHandler() error = %v, want %v", err, errCanNotSplitJSON)
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/thegeeklab/git-sv/app"
@@ -8,6 +9,8 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+var errUnknownPromptStep = errors.New("unknown commit prompt step")
+
 func CommitFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.BoolFlag{
@@ -30,6 +33,10 @@ func CommitFlags() []cli.Flag {
 			Aliases: []string{"nbc"},
 			Usage:   "do not prompt for breaking changes",
 		},
+		&cli.BoolFlag{
+			Name:  "search",
+			Usage: "enable fuzzy search on commit type and scope selection",
+		},
 		&cli.StringFlag{
 			Name:    "type",
 			Aliases: []string{"t"},
@@ -50,6 +57,10 @@ func CommitFlags() []cli.Flag {
 			Aliases: []string{"b"},
 			Usage:   "define commit breaking change message",
 		},
+		&cli.BoolFlag{
+			Name:  "allow-empty",
+			Usage: "allow a commit without changes, e.g. to mark a release point",
+		},
 	}
 }
 
@@ -59,38 +70,52 @@ func CommitHandler(g app.GitSV) cli.ActionFunc {
 		noBody := c.Bool("no-body")
 		noIssue := c.Bool("no-issue")
 		noScope := c.Bool("no-scope")
+		search := c.Bool("search")
 		inputType := c.String("type")
 		inputScope := c.String("scope")
 		inputDescription := c.String("description")
 		inputBreakingChange := c.String("breaking-change")
+		allowEmpty := c.Bool("allow-empty")
 
-		ctype, err := getCommitType(g.Config, g.MessageProcessor, inputType)
-		if err != nil {
-			return err
-		}
+		var (
+			ctype, scope, subject, fullBody, issue, breakingChange string
+			err                                                    error
+		)
 
-		scope, err := getCommitScope(g.Config, g.MessageProcessor, inputScope, noScope)
-		if err != nil {
-			return err
-		}
+		steps := map[string]func() error{
+			app.PromptStepType: func() error {
+				ctype, err = getCommitType(g.Config, g.MessageProcessor, inputType, search)
 
-		subject, err := getCommitDescription(g.MessageProcessor, inputDescription)
-		if err != nil {
-			return err
-		}
+				return err
+			},
+			app.PromptStepScope: func() error {
+				scope, err = getCommitScope(g.Config, g.MessageProcessor, inputScope, noScope, search)
 
-		fullBody, err := getCommitBody(noBody)
-		if err != nil {
-			return err
-		}
+				return err
+			},
+			app.PromptStepDescription: func() error {
+				subject, err = getCommitDescription(g.MessageProcessor, inputDescription)
 
-		issue, err := getCommitIssue(g.Config, g.MessageProcessor, g.Branch(), noIssue)
-		if err != nil {
-			return err
+				return err
+			},
+			app.PromptStepBody: func() error {
+				fullBody, err = getCommitBody(noBody)
+
+				return err
+			},
+			app.PromptStepIssue: func() error {
+				issue, err = getCommitIssue(g.Config, g.MessageProcessor, g.Branch(), noIssue)
+
+				return err
+			},
+			app.PromptStepBreakingChange: func() error {
+				breakingChange, err = getCommitBreakingChange(noBreaking, inputBreakingChange)
+
+				return err
+			},
 		}
 
-		breakingChange, err := getCommitBreakingChange(noBreaking, inputBreakingChange)
-		if err != nil {
+		if err := runCommitPrompts(g.Config.CommitPromptOrder, steps); err != nil {
 			return err
 		}
 
@@ -98,11 +123,35 @@ func CommitHandler(g app.GitSV) cli.ActionFunc {
 			sv.NewCommitMessage(ctype, scope, subject, fullBody, issue, breakingChange),
 		)
 
-		err = g.Commit(header, body, footer)
-		if err != nil {
+		if err := g.Commit(header, body, footer, allowEmpty); err != nil {
 			return fmt.Errorf("error executing git commit: %w", err)
 		}
 
 		return nil
 	}
 }
+
+// runCommitPrompts run the prompt steps in the given order, steps missing in order are run
+// afterwards in the default order.
+func runCommitPrompts(order []string, steps map[string]func() error) error {
+	done := make(map[string]struct{})
+
+	for _, name := range append(append([]string{}, order...), app.DefaultPromptOrder()...) {
+		if _, exists := done[name]; exists {
+			continue
+		}
+
+		step, exists := steps[name]
+		if !exists {
+			return fmt.Errorf("%w: %s", errUnknownPromptStep, name)
+		}
+
+		if err := step(); err != nil {
+			return err
+		}
+
+		done[name] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+)
+
+func Test_runCommitPrompts(t *testing.T) {
+	errPrompt := errors.New("prompt failed")
+
+	tests := []struct {
+		name    string
+		order   []string
+		failing string
+		want    []string
+		wantErr error
+	}{
+		{"default order", app.DefaultPromptOrder(), "", app.DefaultPromptOrder(), nil},
+		{
+			"issue first and body last",
+			[]string{"issue", "type", "scope", "description", "breaking-change", "body"},
+			"",
+			[]string{"issue", "type", "scope", "description", "breaking-change", "body"},
+			nil,
+		},
+		{
+			"missing steps run afterwards",
+			[]string{"issue"},
+			"",
+			[]string{"issue", "type", "scope", "description", "body", "breaking-change"},
+			nil,
+		},
+		{"unknown step", []string{"unknown"}, "", []string{}, errUnknownPromptStep},
+		{"failing step stops prompts", []string{"type", "scope"}, "scope", []string{"type", "scope"}, errPrompt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			steps := make(map[string]func() error)
+
+			for _, name := range app.DefaultPromptOrder() {
+				steps[name] = func() error {
+					got = append(got, name)
+					if name == tt.failing {
+						return errPrompt
+					}
+
+					return nil
+				}
+			}
+
+			if err := runCommitPrompts(tt.order, steps); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runCommitPrompts() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runCommitPrompts() order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
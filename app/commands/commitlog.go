@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/thegeeklab/git-sv/app"
@@ -15,6 +16,7 @@ var (
 	errCanNotCreateTagFlag = errors.New("cannot define tag flag with range, start or end flags")
 	errInvalidRange        = errors.New("invalid log range")
 	errUnknownTag          = errors.New("unknown tag")
+	errCanNotUseNextFlag   = errors.New("cannot define next flag with tag, range, start or end flags")
 )
 
 func CommitLogFlags(settings *app.CommitLogSettings) []cli.Flag {
@@ -45,11 +47,21 @@ func CommitLogFlags(settings *app.CommitLogSettings) []cli.Flag {
 			Usage:       "end range of git log revision range, if date, the value is used on until flag instead",
 			Destination: &settings.End,
 		},
+		&cli.BoolFlag{
+			Name:        "stat",
+			Usage:       "include files changed, insertions and deletions of each commit",
+			Destination: &settings.Stat,
+		},
+		&cli.BoolFlag{
+			Name:        "next",
+			Usage:       "list the commits of the next release grouped by release notes sections",
+			Destination: &settings.Next,
+		},
 	}
 }
 
 func CommitLogHandler(g app.GitSV, settings *app.CommitLogSettings) cli.ActionFunc {
-	return func(_ *cli.Context) error {
+	return func(c *cli.Context) error {
 		var (
 			commits []sv.CommitLog
 			err     error
@@ -63,14 +75,23 @@ func CommitLogHandler(g app.GitSV, settings *app.CommitLogSettings) cli.ActionFu
 			return errCanNotCreateTagFlag
 		}
 
-		if tagFlag == tagDefault {
+		switch {
+		case settings.Next:
+			if tagFlag != tagDefault ||
+				settings.Range != string(app.TagRange) || settings.Start != "" || settings.End != "" {
+				return errCanNotUseNextFlag
+			}
+
+			_, _, _, commits, err = getNextVersionInfo(g, g.CommitProcessor, "", "")
+			commits = groupCommitsBySection(g.Config.ReleaseNotes, commits)
+		case tagFlag == tagDefault:
 			r, rerr := logRange(g, settings.Range, settings.Start, settings.End)
 			if rerr != nil {
 				return rerr
 			}
 
 			commits, err = g.Log(r)
-		} else {
+		default:
 			commits, err = getTagCommits(g, tagFlag)
 		}
 
@@ -79,14 +100,51 @@ func CommitLogHandler(g app.GitSV, settings *app.CommitLogSettings) cli.ActionFu
 		}
 
 		for _, commit := range commits {
+			if settings.Stat {
+				stats, err := g.CommitStats(commit.Hash)
+				if err != nil {
+					return fmt.Errorf("error getting commit stats: %s: %w", commit.Hash, err)
+				}
+
+				commit.Stats = &stats
+			}
+
 			content, err := json.Marshal(commit)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(string(content))
+			fmt.Fprintln(c.App.Writer, string(content))
 		}
 
 		return nil
 	}
 }
+
+// groupCommitsBySection orders commits by the commits sections of the release notes config, sorted by its
+// section-order, commits not mapped to any section are kept at the end in log order.
+func groupCommitsBySection(cfg sv.ReleaseNotesConfig, commits []sv.CommitLog) []sv.CommitLog {
+	result := make([]sv.CommitLog, 0, len(commits))
+	added := make([]bool, len(commits))
+
+	for _, section := range cfg.OrderedSections() {
+		if section.SectionType != sv.ReleaseNotesSectionTypeCommits {
+			continue
+		}
+
+		for i, commit := range commits {
+			if !added[i] && slices.Contains(section.CommitTypes, commit.Message.Type) {
+				result = append(result, commit)
+				added[i] = true
+			}
+		}
+	}
+
+	for i, commit := range commits {
+		if !added[i] {
+			result = append(result, commit)
+		}
+	}
+
+	return result
+}
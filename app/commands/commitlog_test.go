@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCommitLogHandlerNext(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: before release")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "docs: update readme")
+	gitCommit(t, "feat: new feature")
+	gitCommit(t, "fix: second fix")
+
+	settings := &app.CommitLogSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: CommitLogFlags(settings), Action: CommitLogHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--next"}); err != nil {
+		t.Fatalf("CommitLogHandler() error = %v", err)
+	}
+
+	var got, hashes []string
+
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var commit sv.CommitLog
+		if err := json.Unmarshal([]byte(line), &commit); err != nil {
+			t.Fatalf("CommitLogHandler() invalid json output %q: %v", line, err)
+		}
+
+		got = append(got, commit.Message.Description)
+		hashes = append(hashes, commit.Hash)
+	}
+
+	want := []string{"new feature", "second fix", "first fix", "update readme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CommitLogHandler() = %v, want %v", got, want)
+	}
+
+	wantHashes := strings.Fields(git(t, "log", "--format=%h", "1.0.0..HEAD"))
+
+	slices.Sort(hashes)
+	slices.Sort(wantHashes)
+
+	if !reflect.DeepEqual(hashes, wantHashes) {
+		t.Errorf("CommitLogHandler() hashes = %v, want %v", hashes, wantHashes)
+	}
+}
+
+func TestCommitLogHandlerNextSectionOrder(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.ReleaseNotes.SectionOrder = []string{"Bug Fixes"}
+
+	gitCommit(t, "feat: before release")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "docs: update readme")
+	gitCommit(t, "feat: new feature")
+
+	settings := &app.CommitLogSettings{}
+
+	var got []string
+
+	output := runCommand(t, CommitLogFlags(settings), CommitLogHandler(g, settings), "--next")
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var commit sv.CommitLog
+		if err := json.Unmarshal([]byte(line), &commit); err != nil {
+			t.Fatalf("CommitLogHandler() invalid json output %q: %v", line, err)
+		}
+
+		got = append(got, commit.Message.Description)
+	}
+
+	want := []string{"first fix", "new feature", "update readme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CommitLogHandler() = %v, want %v", got, want)
+	}
+}
+
+func TestCommitLogHandlerNextWithRange(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+
+	settings := &app.CommitLogSettings{}
+
+	cliApp := &cli.App{Writer: &bytes.Buffer{}, Flags: CommitLogFlags(settings), Action: CommitLogHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--next", "--start", "1.0.0"}); !errors.Is(err, errCanNotUseNextFlag) {
+		t.Errorf("CommitLogHandler() error = %v, want %v", err, errCanNotUseNextFlag)
+	}
+}
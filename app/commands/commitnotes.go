@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
 	"github.com/urfave/cli/v2"
 )
 
@@ -35,13 +37,16 @@ func CommitNotesFlags(settings *app.CommitNotesSettings) []cli.Flag {
 			Usage:       "output file name. Omit to use standard output.",
 			Destination: &settings.Out,
 		},
+		&cli.IntFlag{
+			Name:        "wrap",
+			Usage:       "hard-wrap the output at the given column width, 0 disables wrapping",
+			Destination: &settings.Wrap,
+		},
 	}
 }
 
 func CommitNotesHandler(g app.GitSV, settings *app.CommitNotesSettings) cli.ActionFunc {
 	return func(_ *cli.Context) error {
-		var date time.Time
-
 		lr, err := logRange(g, settings.Range, settings.Start, settings.End)
 		if err != nil {
 			return err
@@ -52,22 +57,22 @@ func CommitNotesHandler(g app.GitSV, settings *app.CommitNotesSettings) cli.Acti
 			return fmt.Errorf("error getting git log from range: %s: %w", settings.Range, err)
 		}
 
-		if len(commits) > 0 {
-			date, _ = time.Parse("2006-01-02", commits[0].Date)
-		}
+		date := sv.LatestCommitDate(commits, time.Now())
 
 		output, err := g.OutputFormatter.FormatReleaseNote(g.ReleasenotesProcessor.Create(nil, "", date, commits))
 		if err != nil {
 			return fmt.Errorf("could not format commit notes: %w", err)
 		}
 
-		if settings.End == "" {
+		output = formatter.Wrap(output, settings.Wrap)
+
+		if settings.Out == "" {
 			os.Stdout.WriteString(fmt.Sprintf("%s\n", output))
 
 			return nil
 		}
 
-		w, err := os.Create(settings.End)
+		w, err := os.Create(g.Path(settings.Out))
 		if err != nil {
 			return fmt.Errorf("could not write commit notes: %w", err)
 		}
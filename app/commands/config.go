@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/thegeeklab/git-sv/app"
@@ -8,6 +9,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+var errInvalidConfig = errors.New("invalid config")
+
 func ConfigDefaultHandler() cli.ActionFunc {
 	return func(_ *cli.Context) error {
 		cfg := app.GetDefault()
@@ -35,3 +38,40 @@ func ConfigShowHandler(cfg *app.Config) cli.ActionFunc {
 		return nil
 	}
 }
+
+func ConfigValidateHandler(g app.GitSV) cli.ActionFunc {
+	return func(_ *cli.Context) error {
+		for _, w := range g.TagDiagnostics() {
+			fmt.Println("warning: " + w.Error())
+		}
+
+		diagnostics := g.Config.Validate()
+		if len(diagnostics) == 0 {
+			fmt.Println("config is valid")
+
+			return nil
+		}
+
+		for _, d := range diagnostics {
+			fmt.Println(d.Error())
+		}
+
+		return fmt.Errorf("%w: %d issue(s) found", errInvalidConfig, len(diagnostics))
+	}
+}
+
+func ConfigScopesHandler(cfg *app.Config) cli.ActionFunc {
+	return func(_ *cli.Context) error {
+		for _, scope := range cfg.CommitMessage.Scope.Values {
+			if description := cfg.CommitMessage.Scope.Descriptions[scope]; description != "" {
+				fmt.Printf("%s - %s\n", scope, description)
+
+				continue
+			}
+
+			fmt.Println(scope)
+		}
+
+		return nil
+	}
+}
@@ -2,22 +2,57 @@ package commands
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
 
 	"github.com/thegeeklab/git-sv/app"
-	"github.com/thegeeklab/git-sv/sv"
 	"github.com/urfave/cli/v2"
 )
 
-func CurrentVersionHandler(gsv app.GitSV) cli.ActionFunc {
-	return func(_ *cli.Context) error {
+// currentVersionVariables template variables of current-version --format.
+type currentVersionVariables struct {
+	Version string
+	Tag     string
+}
+
+func CurrentVersionFlags(settings *app.CurrentVersionSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "go template used to print the version, with {{.Version}} and {{.Tag}}, e.g. '{{.Tag}}'",
+			Destination: &settings.Format,
+		},
+	}
+}
+
+func CurrentVersionHandler(gsv app.GitSV, settings *app.CurrentVersionSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
 		lastTag := gsv.LastTag()
 
-		currentVer, err := sv.ToVersion(lastTag)
+		currentVer, err := currentVersion(gsv, lastTag)
+		if err != nil {
+			return err
+		}
+
+		version := fmt.Sprintf("%d.%d.%d", currentVer.Major(), currentVer.Minor(), currentVer.Patch())
+
+		if settings.Format == "" {
+			fmt.Fprintln(c.App.Writer, version)
+
+			return nil
+		}
+
+		tmpl, err := template.New("format").Option("missingkey=error").Parse(settings.Format)
 		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag: %w", lastTag, err)
+			return fmt.Errorf("could not parse format: %w", err)
+		}
+
+		var out strings.Builder
+		if err := tmpl.Execute(&out, currentVersionVariables{Version: version, Tag: lastTag}); err != nil {
+			return fmt.Errorf("could not execute format: %w", err)
 		}
 
-		fmt.Printf("%d.%d.%d\n", currentVer.Major(), currentVer.Minor(), currentVer.Patch())
+		fmt.Fprintln(c.App.Writer, out.String())
 
 		return nil
 	}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCurrentVersionHandlerEmptyRepository(t *testing.T) {
+	g := newTestGitSV(t)
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Action: CurrentVersionHandler(g, &app.CurrentVersionSettings{})}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("CurrentVersionHandler() error = %v", err)
+	}
+
+	if got, want := out.String(), "0.0.0\n"; got != want {
+		t.Errorf("CurrentVersionHandler() = %q, want %q", got, want)
+	}
+}
+
+func TestCurrentVersionHandlerVersionFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"valid version", "1.4.2\n", "1.4.2\n", false},
+		{"invalid version", "abc", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV(t)
+			g.Config.Tag.VersionFile = "VERSION"
+
+			if err := os.WriteFile("VERSION", []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			gitCommit(t, "feat: first feature")
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Action: CurrentVersionHandler(g, &app.CurrentVersionSettings{})}
+			if err := cliApp.Run([]string{"git-sv"}); (err != nil) != tt.wantErr {
+				t.Fatalf("CurrentVersionHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("CurrentVersionHandler() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentVersionHandlerFormat(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "v1.2.3")
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "1.2.3\n", false},
+		{"version", "{{.Version}}", "1.2.3\n", false},
+		{"tag", "{{.Tag}}", "v1.2.3\n", false},
+		{"text and variables", "version {{.Version}} from {{.Tag}}", "version 1.2.3 from v1.2.3\n", false},
+		{"invalid template", "{{.Version", "", true},
+		{"unknown variable", "{{.Unknown}}", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			settings := &app.CurrentVersionSettings{}
+			cliApp := &cli.App{
+				Writer: &out,
+				Flags:  CurrentVersionFlags(settings),
+				Action: CurrentVersionHandler(g, settings),
+			}
+
+			if err := cliApp.Run([]string{"git-sv", "--format", tt.format}); (err != nil) != tt.wantErr {
+				t.Fatalf("CurrentVersionHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("CurrentVersionHandler() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentVersionHandlerAfterBumpPackageJSON(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.Tag.VersionFile = "package.json"
+
+	packageJSON := "{\n  \"name\": \"pkg\",\n  \"version\": \"1.0.0\"\n}\n"
+	if err := os.WriteFile("package.json", []byte(packageJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	git(t, "add", "package.json")
+	gitCommit(t, "feat: first feature")
+
+	bumpSettings := &app.BumpSettings{}
+	if got := runCommand(t, BumpFlags(bumpSettings), BumpHandler(g, bumpSettings), "--commit"); got != "1.1.0\n" {
+		t.Fatalf("BumpHandler() = %q, want %q", got, "1.1.0\n")
+	}
+
+	settings := &app.CurrentVersionSettings{}
+	if got := runCommand(t, CurrentVersionFlags(settings), CurrentVersionHandler(g, settings)); got != "1.1.0\n" {
+		t.Errorf("CurrentVersionHandler() = %q, want %q", got, "1.1.0\n")
+	}
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func DiffFlags(settings *app.DiffSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "from",
+			Usage:       "older tag of the range",
+			Required:    true,
+			Destination: &settings.From,
+		},
+		&cli.StringFlag{
+			Name:        "to",
+			Usage:       "newer tag of the range",
+			Required:    true,
+			Destination: &settings.To,
+		},
+		&cli.BoolFlag{
+			Name:        "inclusive",
+			Usage:       "include release notes of the from tag",
+			Destination: &settings.Inclusive,
+		},
+		&cli.StringFlag{
+			Name:        "o",
+			Aliases:     []string{"output"},
+			Usage:       "output file name. Omit to use standard output.",
+			Destination: &settings.Out,
+		},
+	}
+}
+
+func DiffHandler(g app.GitSV, settings *app.DiffSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		tags, err := g.Tags()
+		if err != nil {
+			return err
+		}
+
+		selected, err := app.TagsBetween(tags, settings.From, settings.To, settings.Inclusive)
+		if err != nil {
+			return err
+		}
+
+		releaseNotes := make([]sv.ReleaseNote, 0, len(selected))
+
+		for i := len(selected) - 1; i >= 0; i-- {
+			tag := selected[i]
+
+			previousTag := ""
+			if index := find(tag.Name, tags); index > 0 {
+				previousTag = tags[index-1].Name
+			}
+
+			commits, err := g.Log(app.NewLogRange(app.TagRange, previousTag, tag.Name))
+			if err != nil {
+				return fmt.Errorf("error getting git log from tag: %s: %w", tag.Name, err)
+			}
+
+			currentVer, _ := sv.ToVersion(tag.Name)
+			releaseNotes = append(releaseNotes, g.ReleasenotesProcessor.Create(currentVer, tag.Name, tag.Date, commits))
+		}
+
+		output, err := g.OutputFormatter.FormatChangelog(releaseNotes)
+		if err != nil {
+			return fmt.Errorf("could not format release notes diff: %w", err)
+		}
+
+		if settings.Out == "" {
+			fmt.Fprintf(c.App.Writer, "%s\n", output)
+
+			return nil
+		}
+
+		w, err := os.Create(g.Path(settings.Out))
+		if err != nil {
+			return fmt.Errorf("could not write release notes diff: %w", err)
+		}
+		defer w.Close()
+
+		if _, err := w.Write(output); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/thegeeklab/git-sv/templates"
+)
+
+func TestDiffHandler(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: before lower tag")
+	gitCommit(t, "feat: at lower tag")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: first intermediate fix")
+	git(t, "tag", "1.0.1")
+	gitCommit(t, "feat: intermediate feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "fix: before upper tag")
+	gitCommit(t, "feat: at upper tag")
+	git(t, "tag", "1.2.0")
+	gitCommit(t, "feat: after upper tag")
+	git(t, "tag", "1.3.0")
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantTags    []string
+		wantCommits []string
+		wantMissing []string
+	}{
+		{
+			"exclusive", []string{"--from", "1.0.0", "--to", "1.2.0"},
+			[]string{"1.2.0", "1.1.0", "1.0.1"},
+			[]string{"first intermediate fix", "intermediate feature", "before upper tag", "at upper tag"},
+			[]string{"1.0.0", "1.3.0", "before lower tag", "at lower tag", "after upper tag"},
+		},
+		{
+			"inclusive", []string{"--from", "1.0.0", "--to", "1.2.0", "--inclusive"},
+			[]string{"1.2.0", "1.1.0", "1.0.1", "1.0.0"},
+			[]string{"before lower tag", "at lower tag", "first intermediate fix", "at upper tag"},
+			[]string{"1.3.0", "after upper tag"},
+		},
+		{
+			"adjacent tags", []string{"--from", "1.1.0", "--to", "1.2.0"},
+			[]string{"1.2.0"},
+			[]string{"before upper tag", "at upper tag"},
+			[]string{"1.1.0", "intermediate feature"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.DiffSettings{}
+			got := runCommand(t, DiffFlags(settings), DiffHandler(g, settings), tt.args...)
+
+			last := -1
+			for _, tag := range tt.wantTags {
+				index := strings.Index(got, "## v"+tag+" ")
+				if index < 0 || index < last {
+					t.Errorf("DiffHandler() = %s, want release %s in descending order", got, tag)
+				}
+
+				last = index
+			}
+
+			for _, commit := range tt.wantCommits {
+				if !strings.Contains(got, commit) {
+					t.Errorf("DiffHandler() = %s, want commit %q", got, commit)
+				}
+			}
+
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(got, "## v"+missing+" ") || strings.Contains(got, "- "+missing+" ") {
+					t.Errorf("DiffHandler() = %s, want no %q", got, missing)
+				}
+			}
+		})
+	}
+}
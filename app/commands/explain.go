@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func ExplainHandler(g app.GitSV) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		lastTag := g.LastTag()
+
+		currentVer, err := currentVersion(g, lastTag)
+		if err != nil {
+			return err
+		}
+
+		commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, "HEAD"))
+		if err != nil {
+			return fmt.Errorf("error getting git log: %w", err)
+		}
+
+		warnInvalidBumpFooters(g.Config, commits)
+
+		explainNextVersion(c.App.Writer, g, lastTag, currentVer, commits)
+
+		return nil
+	}
+}
+
+func explainNextVersion(w io.Writer, g app.GitSV, lastTag string, currentVer *semver.Version, commits []sv.CommitLog) {
+	switch {
+	case lastTag == "" && g.Config.Tag.VersionFile != "":
+		fmt.Fprintf(w, "current version: %s (version file %s)\n", currentVer, g.Config.Tag.VersionFile)
+	case lastTag == "":
+		fmt.Fprintf(w, "current version: %s (no tag found)\n", currentVer)
+	default:
+		fmt.Fprintf(w, "current version: %s (tag %s)\n", currentVer, lastTag)
+	}
+
+	fmt.Fprintf(w, "commits since last release: %d\n", len(commits))
+
+	highest := ""
+	ignored := 0
+
+	for _, commit := range commits {
+		bump, reason := g.CommitProcessor.Bump(commit)
+		if bump == "" {
+			ignored++
+
+			continue
+		}
+
+		header, _, _ := g.MessageProcessor.Format(commit.Message)
+
+		fmt.Fprintf(w, "  - %s %s: %s (%s)\n", commit.Hash, header, bump, reason)
+
+		if bumpLevel(bump) > bumpLevel(highest) {
+			highest = bump
+		}
+	}
+
+	fmt.Fprintf(w, "ignored commits: %d\n", ignored)
+
+	nextVer, updated := g.CommitProcessor.NextVersion(currentVer, commits)
+	if !updated {
+		if highest != "" {
+			fmt.Fprintf(w, "highest bump: none (minimum of %d releasable commits not reached)\n",
+				g.Config.Versioning.MinCommits)
+		} else {
+			fmt.Fprintln(w, "highest bump: none")
+		}
+
+		fmt.Fprintf(w, "next version: %s (unchanged)\n", currentVer)
+
+		return
+	}
+
+	fmt.Fprintf(w, "highest bump: %s\nnext version: %s\n", highest, nextVer)
+}
+
+func bumpLevel(bump string) int {
+	switch bump {
+	case sv.BumpMajor:
+		return 3 //nolint:mnd
+	case sv.BumpMinor:
+		return 2 //nolint:mnd
+	case sv.BumpPatch:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func TestExplainHandler(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: some fix")
+	gitCommit(t, "revert: undo readme change")
+	gitCommit(t, "feat: second feature")
+	gitCommit(t, "refactor!: drop legacy api")
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Action: ExplainHandler(g)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("ExplainHandler() error = %v", err)
+	}
+
+	got := out.String()
+
+	for _, want := range []string{
+		"current version: 1.0.0 (tag 1.0.0)\n",
+		"commits since last release: 4\n",
+		" refactor: drop legacy api: major (breaking change)\n",
+		" feat: second feature: minor (type feat)\n",
+		" fix: some fix: patch (type fix)\n",
+		"ignored commits: 1\n",
+		"highest bump: major\n",
+		"next version: 2.0.0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ExplainHandler() output = %s, want to contain %q", got, want)
+		}
+	}
+
+	if strings.Contains(got, "undo readme change") {
+		t.Errorf("ExplainHandler() output = %s, want revert commit not listed", got)
+	}
+}
+
+func TestExplainHandlerUnchanged(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "revert: undo readme change")
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Action: ExplainHandler(g)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("ExplainHandler() error = %v", err)
+	}
+
+	if want := "highest bump: none\nnext version: 1.0.0 (unchanged)\n"; !strings.HasSuffix(out.String(), want) {
+		t.Errorf("ExplainHandler() output = %s, want suffix %q", out.String(), want)
+	}
+}
+
+func TestExplainHandlerBumpRules(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.CommitMessage.Footer["version-bump"] = sv.CommitMessageFooterConfig{Key: "Version-Bump"}
+	g.Config.Versioning.BumpFooter = "version-bump"
+	g.Config.Versioning.ScopeMinBump = map[string]string{"api": sv.BumpMinor}
+	g.Config.Versioning.MaxBump = sv.BumpMinor
+	g.MessageProcessor = sv.NewMessageProcessor(g.Config.CommitMessage, g.Config.Branches)
+	g.CommitProcessor = sv.NewSemVerCommitProcessor(g.Config.Versioning, g.Config.CommitMessage)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: some fix")
+	gitCommit(t, "feat: second feature\n\nVersion-Bump: patch")
+	gitCommit(t, "docs(api): document endpoint")
+	gitCommit(t, "refactor!: drop legacy api")
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Action: ExplainHandler(g)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("ExplainHandler() error = %v", err)
+	}
+
+	got := out.String()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"type", " fix: some fix: patch (type fix)\n"},
+		{"bump footer", " feat: second feature: patch (version-bump footer)\n"},
+		{"scope minimum", " docs(api): document endpoint: minor (scope api minimum)\n"},
+		{"max bump", " refactor: drop legacy api: minor (max-bump caps breaking change)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("ExplainHandler() output = %s, want to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	if want := "highest bump: minor\nnext version: 1.1.0\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("ExplainHandler() output = %s, want suffix %q", got, want)
+	}
+}
@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/rs/zerolog/log"
 	"github.com/thegeeklab/git-sv/app"
@@ -9,29 +12,167 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-func NextVersionHandler(g app.GitSV) cli.ActionFunc {
-	return func(_ *cli.Context) error {
-		lastTag := g.LastTag()
+var errUnknownOutputFormat = errors.New("unknown output format")
 
-		currentVer, err := sv.ToVersion(lastTag)
+type versionOutput struct {
+	Version string `json:"version"`
+	// PreviousTag raw name of the tag the version is computed from, e.g. "v1.0.0".
+	PreviousTag     string `json:"previousTag,omitempty"`
+	PreviousVersion string `json:"previousVersion,omitempty"`
+}
+
+type bumpCountsOutput struct {
+	Releasable int `json:"releasable"`
+	Major      int `json:"major"`
+	Minor      int `json:"minor"`
+	Patch      int `json:"patch"`
+}
+
+func NextVersionFlags(settings *app.NextVersionSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "snapshot",
+			Usage:       "append the configured snapshot suffix to the next version",
+			Destination: &settings.Snapshot,
+		},
+		&cli.StringFlag{
+			Name:        "base-tag",
+			Usage:       "compute the next version from commits since the given tag instead of the last tag",
+			Destination: &settings.BaseTag,
+		},
+		&cli.BoolFlag{
+			Name:        "count-only",
+			Usage:       "print the number of releasable commits by bump level instead of the version",
+			Destination: &settings.CountOnly,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, use: text or json (default: config default-output-format)",
+			Destination: &settings.Format,
+		},
+		&cli.StringFlag{
+			Name:        "remote-branch",
+			Usage:       "compute the next version from the commits of a remote-tracking branch, e.g. origin/main",
+			Destination: &settings.RemoteBranch,
+		},
+		&cli.BoolFlag{
+			Name:        "pre-release",
+			Usage:       "compute the next pre-release version, e.g. 1.2.0-rc.1, with versioning.pre-release",
+			Destination: &settings.PreRelease,
+		},
+		&cli.BoolFlag{
+			Name:        "previous",
+			Usage:       "include the previous tag and version in the json output",
+			Destination: &settings.Previous,
+		},
+	}
+}
+
+func NextVersionHandler(g app.GitSV, settings *app.NextVersionSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		format, err := outputFormat(settings.Format, g.Config)
+		if err != nil {
+			return err
+		}
+
+		lastTag := str(settings.BaseTag, g.LastTag())
+
+		ref := ""
+		if settings.RemoteBranch != "" {
+			if ref, err = g.RemoteBranch(settings.RemoteBranch); err != nil {
+				return err
+			}
+		}
+
+		if settings.CountOnly {
+			commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, ref))
+			if err != nil {
+				return fmt.Errorf("error getting git log: %w", err)
+			}
+
+			counts := g.CommitProcessor.CountBumps(commits)
+			if format == app.OutputFormatJSON {
+				return writeJSON(c.App.Writer, bumpCountsOutput{counts.Releasable(), counts.Major, counts.Minor, counts.Patch})
+			}
+
+			fmt.Fprintf(c.App.Writer, "releasable: %d\nmajor: %d\nminor: %d\npatch: %d\n",
+				counts.Releasable(), counts.Major, counts.Minor, counts.Patch)
+
+			return nil
+		}
+
+		currentVer, err := currentVersion(g, lastTag)
 		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag: %w", lastTag, err)
+			return err
 		}
 
-		commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, ""))
+		nextVer, updated, _, _, err := getNextVersionInfo(g, g.CommitProcessor, lastTag, ref)
 		if err != nil {
-			return fmt.Errorf("error getting git log: %w", err)
+			return err
 		}
 
-		nextVer, updated := g.CommitProcessor.NextVersion(currentVer, commits)
 		if !updated {
 			log.Info().Msgf("nothing to do: current version %s unchanged", currentVer)
 
 			return nil
 		}
 
-		fmt.Printf("%d.%d.%d\n", nextVer.Major(), nextVer.Minor(), nextVer.Patch())
+		if settings.PreRelease {
+			preRelease, updated, err := preReleaseVersion(g, currentVer, *nextVer, ref)
+			if err != nil {
+				return err
+			}
+
+			if !updated {
+				log.Info().Msgf("nothing to do: no releasable commit since %s", preRelease)
+
+				return nil
+			}
+
+			nextVer = preRelease
+		}
+
+		version := versionString(nextVer)
+		if settings.Snapshot {
+			version = sv.SnapshotVersion(*nextVer, g.Config.Versioning.SnapshotSuffix)
+		}
+
+		if format == app.OutputFormatJSON {
+			output := versionOutput{Version: version}
+			if settings.Previous {
+				output.PreviousTag = lastTag
+				output.PreviousVersion = currentVer.String()
+			}
+
+			return writeJSON(c.App.Writer, output)
+		}
+
+		fmt.Fprintln(c.App.Writer, version)
 
 		return nil
 	}
 }
+
+// outputFormat return the format flag value or the configured default if the flag is empty.
+func outputFormat(flag string, cfg *app.Config) (string, error) {
+	format := str(flag, str(cfg.DefaultOutputFormat, app.OutputFormatText))
+
+	switch format {
+	case app.OutputFormatText, app.OutputFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %s, expected: %s or %s", errUnknownOutputFormat, format,
+			app.OutputFormatText, app.OutputFormatJSON)
+	}
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(content))
+
+	return err
+}
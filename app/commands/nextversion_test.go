@@ -0,0 +1,367 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/thegeeklab/git-sv/templates"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_outputFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		flag          string
+		defaultFormat string
+		want          string
+		wantErr       error
+	}{
+		{"builtin default", "", "", app.OutputFormatText, nil},
+		{"config default", "", app.OutputFormatJSON, app.OutputFormatJSON, nil},
+		{"flag overrides config", app.OutputFormatText, app.OutputFormatJSON, app.OutputFormatText, nil},
+		{"unknown format", "yaml", app.OutputFormatText, "", errUnknownOutputFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := app.GetDefault()
+			cfg.DefaultOutputFormat = tt.defaultFormat
+
+			got, err := outputFormat(tt.flag, cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("outputFormat() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("outputFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextVersionHandlerFormat(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.DefaultOutputFormat = app.OutputFormatJSON
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"config default", []string{}, `{"version":"1.1.0"}` + "\n"},
+		{"flag override", []string{"--format", "text"}, "1.1.0\n"},
+		{"count only", []string{"--count-only"}, `{"releasable":1,"major":0,"minor":1,"patch":0}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			settings := &app.NextVersionSettings{}
+			cliApp := &cli.App{
+				Writer: &out,
+				Flags:  NextVersionFlags(settings),
+				Action: NextVersionHandler(g, settings),
+			}
+
+			if err := cliApp.Run(append([]string{"git-sv"}, tt.args...)); err != nil {
+				t.Fatalf("NextVersionHandler() error = %v", err)
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("NextVersionHandler() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextVersionHandlerPrevious(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "v1.0.0")
+	gitCommit(t, "fix: first fix")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			"json", []string{"--format", "json", "--previous"},
+			`{"version":"1.0.1","previousTag":"v1.0.0","previousVersion":"1.0.0"}` + "\n",
+		},
+		{"json without previous", []string{"--format", "json"}, `{"version":"1.0.1"}` + "\n"},
+		{"text", []string{"--format", "text", "--previous"}, "1.0.1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			settings := &app.NextVersionSettings{}
+			cliApp := &cli.App{
+				Writer: &out,
+				Flags:  NextVersionFlags(settings),
+				Action: NextVersionHandler(g, settings),
+			}
+
+			if err := cliApp.Run(append([]string{"git-sv"}, tt.args...)); err != nil {
+				t.Fatalf("NextVersionHandler() error = %v", err)
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("NextVersionHandler() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextVersionHandlerEmptyRepository(t *testing.T) {
+	g := newTestGitSV(t)
+
+	var buf bytes.Buffer
+
+	logger := log.Logger
+	log.Logger = zerolog.New(&buf)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	settings := &app.NextVersionSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: NextVersionFlags(settings), Action: NextVersionHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("NextVersionHandler() error = %v", err)
+	}
+
+	if got := out.String(); got != "" {
+		t.Errorf("NextVersionHandler() = %q, want empty output", got)
+	}
+
+	for _, want := range []string{"repository has no commits", "current version 0.0.0 unchanged"} {
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Errorf("NextVersionHandler() log = %s, want to contain %q", got, want)
+		}
+	}
+}
+
+func TestNextVersionHandlerVersionFile(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.Tag.VersionFile = "VERSION"
+
+	if err := os.WriteFile("VERSION", []byte("1.4.2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	gitCommit(t, "feat: new feature")
+
+	settings := &app.NextVersionSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: NextVersionFlags(settings), Action: NextVersionHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("NextVersionHandler() error = %v", err)
+	}
+
+	if got, want := out.String(), "1.5.0\n"; got != want {
+		t.Errorf("NextVersionHandler() = %q, want %q", got, want)
+	}
+
+	git(t, "tag", "2.0.0")
+	gitCommit(t, "fix: some fix")
+	out.Reset()
+
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("NextVersionHandler() error = %v", err)
+	}
+
+	if got, want := out.String(), "2.0.1\n"; got != want {
+		t.Errorf("NextVersionHandler() with tag = %q, want %q", got, want)
+	}
+}
+
+func TestNextVersionHandlerRemoteBranch(t *testing.T) {
+	g := newTestGitSV(t)
+
+	remote := t.TempDir()
+	git(t, "init", "-q", "--bare", remote)
+	git(t, "remote", "add", "origin", remote)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "fix: local fix")
+	gitCommit(t, "feat: remote feature")
+	git(t, "push", "-q", "origin", "main")
+	git(t, "reset", "-q", "--hard", "HEAD~1")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{"local head", []string{}, "1.0.1\n", false},
+		{"remote branch", []string{"--remote-branch", "origin/main"}, "1.1.0\n", false},
+		{
+			"remote branch count", []string{"--remote-branch", "origin/main", "--count-only"},
+			"releasable: 2\nmajor: 0\nminor: 1\npatch: 1\n", false,
+		},
+		{"unknown remote branch", []string{"--remote-branch", "origin/unknown"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			settings := &app.NextVersionSettings{}
+			cliApp := &cli.App{
+				Writer: &out,
+				Flags:  NextVersionFlags(settings),
+				Action: NextVersionHandler(g, settings),
+			}
+
+			err := cliApp.Run(append([]string{"git-sv"}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextVersionHandler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("NextVersionHandler() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextVersionHandlerPreRelease(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "feat: second feature")
+
+	nextVersion := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+
+		var out bytes.Buffer
+
+		settings := &app.NextVersionSettings{}
+		cliApp := &cli.App{Writer: &out, Flags: NextVersionFlags(settings), Action: NextVersionHandler(g, settings)}
+		err := cliApp.Run(append([]string{"git-sv"}, args...))
+
+		return out.String(), err
+	}
+
+	if _, err := nextVersion(t, "--pre-release"); err == nil {
+		t.Errorf("NextVersionHandler() error = %v, want pre-release not configured", err)
+	}
+
+	g.Config.Versioning.PreRelease = sv.PreReleaseConfig{Name: "rc"}
+	g.CommitProcessor = sv.NewSemVerCommitProcessor(g.Config.Versioning, g.Config.CommitMessage)
+
+	steps := []struct {
+		name string
+		args []string
+		tag  string
+		want string
+	}{
+		{"first pre-release", []string{"--pre-release"}, "1.2.0-rc.1", "1.2.0-rc.1\n"},
+		{"second pre-release", []string{"--pre-release"}, "1.2.0-rc.2", "1.2.0-rc.2\n"},
+		{"final release", []string{}, "", "1.2.0\n"},
+	}
+	for _, step := range steps {
+		gitCommit(t, "fix: "+step.name)
+
+		got, err := nextVersion(t, step.args...)
+		if err != nil {
+			t.Fatalf("NextVersionHandler() %s error = %v", step.name, err)
+		}
+
+		if got != step.want {
+			t.Errorf("NextVersionHandler() %s = %q, want %q", step.name, got, step.want)
+		}
+
+		if step.tag != "" {
+			git(t, "tag", step.tag)
+		}
+	}
+}
+
+func TestNextVersionHandlerPreReleaseCounter(t *testing.T) {
+	for _, exclude := range []bool{false, true} {
+		t.Run(fmt.Sprintf("exclude prereleases %t", exclude), func(t *testing.T) {
+			g := newTestGitSV(t)
+			g.Settings.ExcludePrereleases = exclude
+			g.Config.Versioning.PreRelease = sv.PreReleaseConfig{Name: "rc"}
+			g.CommitProcessor = sv.NewSemVerCommitProcessor(g.Config.Versioning, g.Config.CommitMessage)
+
+			gitCommit(t, "feat: first feature")
+			git(t, "tag", "1.1.0")
+			gitCommit(t, "feat: second feature")
+			git(t, "tag", "1.2.0-rc.1")
+
+			steps := []struct {
+				name   string
+				commit string
+				want   string
+			}{
+				{"no commit since pre-release", "", ""},
+				{"fix since pre-release", "fix: first fix", "1.2.0-rc.2\n"},
+			}
+			for _, step := range steps {
+				if step.commit != "" {
+					gitCommit(t, step.commit)
+				}
+
+				settings := &app.NextVersionSettings{}
+				got := runCommand(t, NextVersionFlags(settings), NextVersionHandler(g, settings), "--pre-release")
+
+				if got != step.want {
+					t.Errorf("NextVersionHandler() %s = %q, want %q", step.name, got, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNextVersionHandlerIgnoreScopes(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "chore(deps): bump lib")
+
+	nextSettings := &app.NextVersionSettings{}
+	if got := runCommand(t, NextVersionFlags(nextSettings), NextVersionHandler(g, nextSettings)); got != "1.0.1\n" {
+		t.Errorf("NextVersionHandler() = %q, want %q", got, "1.0.1\n")
+	}
+
+	g.Config.IgnoreScopes = []string{"deps"}
+
+	if got := runCommand(t, NextVersionFlags(nextSettings), NextVersionHandler(g, nextSettings)); got != "" {
+		t.Errorf("NextVersionHandler() = %q, want no version", got)
+	}
+
+	gitCommit(t, "fix(api): first fix")
+
+	rnSettings := &app.ReleaseNotesSettings{}
+
+	got := runCommand(t, ReleaseNotesFlags(rnSettings), ReleaseNotesHandler(g, rnSettings))
+	if !strings.Contains(got, "first fix") || strings.Contains(got, "bump lib") {
+		t.Errorf("ReleaseNotesHandler() = %s, want first fix without deps commit", got)
+	}
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/urfave/cli/v2"
+)
+
+type planOutput struct {
+	CurrentVersion string          `json:"currentVersion"`
+	Version        string          `json:"version"`
+	Updated        bool            `json:"updated"`
+	Tag            string          `json:"tag"`
+	Sections       []sectionOutput `json:"sections"`
+	ReleaseNotes   string          `json:"releaseNotes"`
+}
+
+// sectionOutput json serialization of a release notes section, shared by plan and changelog.
+type sectionOutput struct {
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	Commits  []sv.CommitLog `json:"commits,omitempty"`
+	Messages []string       `json:"messages,omitempty"`
+}
+
+func PlanFlags(settings *app.PlanSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "release notes format, use: md, rst, table or github",
+			Value:       formatter.FormatMarkdown,
+			Destination: &settings.Format,
+		},
+	}
+}
+
+func PlanHandler(g app.GitSV, settings *app.PlanSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if err := validateReleaseNoteFormat(settings.Format); err != nil {
+			return err
+		}
+
+		lastTag := g.LastTag()
+
+		currentVer, err := currentVersion(g, lastTag)
+		if err != nil {
+			return err
+		}
+
+		nextVer, updated, date, commits, err := getNextVersionInfo(g, g.CommitProcessor, lastTag, "")
+		if err != nil {
+			return err
+		}
+
+		releasenote := g.ReleasenotesProcessor.Create(nextVer, "", date, commits)
+		releasenote.Compare = releaseCompare(g, lastTag, "", nextVer)
+
+		output, err := g.OutputFormatter.FormatReleaseNoteAs(settings.Format, releasenote)
+		if err != nil {
+			return fmt.Errorf("could not format release notes: %w", err)
+		}
+
+		plan := planOutput{
+			CurrentVersion: currentVer.String(),
+			Version:        fmt.Sprintf("%d.%d.%d", nextVer.Major(), nextVer.Minor(), nextVer.Patch()),
+			Updated:        updated,
+			Tag:            fmt.Sprintf(*g.Config.Tag.Pattern, nextVer.Major(), nextVer.Minor(), nextVer.Patch()),
+			Sections:       planSections(releasenote),
+			ReleaseNotes:   string(output),
+		}
+
+		return writeJSON(c.App.Writer, plan)
+	}
+}
+
+// planSections return the commits sections of the release note, empty sections are omitted.
+func planSections(releasenote sv.ReleaseNote) []sectionOutput {
+	sections := make([]sectionOutput, 0, len(releasenote.Sections))
+
+	for _, section := range releasenote.Sections {
+		if commitsSection, ok := section.(sv.ReleaseNoteCommitsSection); ok && len(commitsSection.Items) > 0 {
+			sections = append(sections, newSectionOutput(section))
+		}
+	}
+
+	return sections
+}
+
+// newSectionOutput return the json serialization of a section, with the commits of commits sections
+// and the messages of breaking changes sections.
+func newSectionOutput(section sv.ReleaseNoteSection) sectionOutput {
+	output := sectionOutput{Name: section.SectionName(), Type: section.SectionType()}
+
+	switch s := section.(type) {
+	case sv.ReleaseNoteCommitsSection:
+		output.Commits = s.Items
+	case sv.ReleaseNoteBreakingChangeSection:
+		output.Messages = s.Messages
+	}
+
+	return output
+}
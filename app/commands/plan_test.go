@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/thegeeklab/git-sv/templates"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPlanHandler(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+	gitCommit(t, "fix: first fix")
+	gitCommit(t, "chore: not released")
+
+	planSettings := &app.PlanSettings{}
+
+	var got planOutput
+
+	output := runCommand(t, PlanFlags(planSettings), PlanHandler(g, planSettings))
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("PlanHandler() invalid json: %v", err)
+	}
+
+	nextSettings := &app.NextVersionSettings{}
+	nextVersion := runCommand(t, NextVersionFlags(nextSettings), NextVersionHandler(g, nextSettings))
+
+	if got.Version+"\n" != nextVersion {
+		t.Errorf("PlanHandler() version = %s, want %s", got.Version, nextVersion)
+	}
+
+	if got.CurrentVersion != "1.0.0" || !got.Updated || got.Tag != "1.1.0" {
+		t.Errorf("PlanHandler() = %+v, want current version 1.0.0, updated and tag 1.1.0", got)
+	}
+
+	rnSettings := &app.ReleaseNotesSettings{}
+	releaseNotes := runCommand(t, ReleaseNotesFlags(rnSettings), ReleaseNotesHandler(g, rnSettings))
+
+	if got.ReleaseNotes+"\n" != releaseNotes {
+		t.Errorf("PlanHandler() release notes = %s, want %s", got.ReleaseNotes, releaseNotes)
+	}
+
+	if len(got.Sections) != 2 {
+		t.Fatalf("PlanHandler() sections = %+v, want 2 sections", got.Sections)
+	}
+
+	for i, want := range []struct {
+		name        string
+		description string
+	}{
+		{"Features", "second feature"},
+		{"Bug Fixes", "first fix"},
+	} {
+		section := got.Sections[i]
+		if section.Name != want.name || len(section.Commits) != 1 ||
+			section.Commits[0].Message.Description != want.description {
+			t.Errorf("PlanHandler() section = %+v, want %s with %s", section, want.name, want.description)
+		}
+	}
+}
+
+func TestPlanHandlerUnknownFormat(t *testing.T) {
+	g := newTestGitSV(t)
+	gitCommit(t, "feat: first feature")
+
+	settings := &app.PlanSettings{}
+
+	cliApp := &cli.App{Flags: PlanFlags(settings), Action: PlanHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv", "--format", "html"}); !errors.Is(err, errUnknownOutputFormat) {
+		t.Errorf("PlanHandler() error = %v, want %v", err, errUnknownOutputFormat)
+	}
+}
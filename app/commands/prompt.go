@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/manifoldco/promptui"
+	"github.com/manifoldco/promptui/list"
 )
 
+type commitScope struct {
+	Scope       string
+	Description string
+}
+
 type commitType struct {
 	Type        string
 	Description string
@@ -17,7 +24,7 @@ type commitType struct {
 
 var errInvalidValue = errors.New("invalid value")
 
-func promptType(types []string) (commitType, error) {
+func promptType(types []string, search bool) (commitType, error) {
 	defaultTypes := map[string]commitType{
 		"build": {
 			Type:        "build",
@@ -90,7 +97,14 @@ func promptType(types []string) (commitType, error) {
 {{ "Example:" | faint }}	{{ .Example }}`,
 	}
 
-	i, err := promptSelect("type", items, template)
+	var searcher list.Searcher
+	if search {
+		searcher = func(input string, index int) bool {
+			return fuzzyMatch(input, items[index].Type) || fuzzyMatch(input, items[index].Description)
+		}
+	}
+
+	i, err := promptSelect("type", items, template, searcher)
 	if err != nil {
 		return commitType{}, err
 	}
@@ -98,9 +112,44 @@ func promptType(types []string) (commitType, error) {
 	return items[i], nil
 }
 
-func promptScope(values []string) (string, error) {
+func promptScope(values []string, descriptions map[string]string, search bool) (string, error) {
+	if len(values) > 0 && len(descriptions) > 0 {
+		items := make([]commitScope, len(values))
+		for i, v := range values {
+			items[i] = commitScope{Scope: v, Description: descriptions[v]}
+		}
+
+		template := &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "> {{ .Scope | white }} - {{ .Description | faint }}",
+			Inactive: "  {{ .Scope | white }} - {{ .Description | faint }}",
+			Selected: `{{ "scope:" | faint }} {{ .Scope | white }}`,
+		}
+
+		var searcher list.Searcher
+		if search {
+			searcher = func(input string, index int) bool {
+				return fuzzyMatch(input, items[index].Scope) || fuzzyMatch(input, items[index].Description)
+			}
+		}
+
+		selected, err := promptSelect("scope", items, template, searcher)
+		if err != nil {
+			return "", err
+		}
+
+		return items[selected].Scope, nil
+	}
+
 	if len(values) > 0 {
-		selected, err := promptSelect("scope", values, nil)
+		var searcher list.Searcher
+		if search {
+			searcher = func(input string, index int) bool {
+				return fuzzyMatch(input, values[index])
+			}
+		}
+
+		selected, err := promptSelect("scope", values, nil, searcher)
 		if err != nil {
 			return "", err
 		}
@@ -127,16 +176,20 @@ func promptBreakingChanges() (string, error) {
 	return promptText("Breaking change description", "[a-z].+", "")
 }
 
-func promptSelect(label string, items interface{}, template *promptui.SelectTemplates) (int, error) {
+func promptSelect(
+	label string, items interface{}, template *promptui.SelectTemplates, searcher list.Searcher,
+) (int, error) {
 	if items == nil || reflect.TypeOf(items).Kind() != reflect.Slice {
 		return 0, fmt.Errorf("%w: %v is not a slice", errInvalidValue, items)
 	}
 
 	prompt := promptui.Select{
-		Label:     label,
-		Size:      reflect.ValueOf(items).Len(),
-		Items:     items,
-		Templates: template,
+		Label:             label,
+		Size:              reflect.ValueOf(items).Len(),
+		Items:             items,
+		Templates:         template,
+		Searcher:          searcher,
+		StartInSearchMode: searcher != nil,
 	}
 
 	index, _, err := prompt.Run()
@@ -144,6 +197,23 @@ func promptSelect(label string, items interface{}, template *promptui.SelectTemp
 	return index, err
 }
 
+// fuzzyMatch return true if all input characters appear in value in the same order, ignoring case.
+func fuzzyMatch(input, value string) bool {
+	input = strings.ToLower(strings.ReplaceAll(input, " ", ""))
+	value = strings.ToLower(value)
+
+	for _, r := range input {
+		i := strings.IndexRune(value, r)
+		if i < 0 {
+			return false
+		}
+
+		value = value[i+len(string(r)):]
+	}
+
+	return true
+}
+
 func promptText(label, regex, defaultValue string) (string, error) {
 	validate := func(input string) error {
 		regex := regexp.MustCompile(regex)
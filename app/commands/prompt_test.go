@@ -0,0 +1,29 @@
+package commands
+
+import "testing"
+
+func Test_fuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		value string
+		want  bool
+	}{
+		{"empty input", "", "feat", true},
+		{"exact match", "feat", "feat", true},
+		{"prefix", "fe", "feature", true},
+		{"subsequence", "rfct", "refactor", true},
+		{"ignore case", "API", "public-api", true},
+		{"ignore spaces", "bug fix", "a bug fix", true},
+		{"wrong order", "tf", "feat", false},
+		{"missing character", "fx", "feat", false},
+		{"longer input", "features", "feat", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fuzzyMatch(tt.input, tt.value); got != tt.want {
+				t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.input, tt.value, got, tt.want)
+			}
+		})
+	}
+}
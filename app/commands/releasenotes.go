@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,9 +10,12 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/thegeeklab/git-sv/app"
 	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
 	"github.com/urfave/cli/v2"
 )
 
+var errCanNotUseSinceStable = errors.New("cannot define since-stable flag with tag or latest flags")
+
 func ReleaseNotesFlags(settings *app.ReleaseNotesSettings) []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
@@ -27,11 +31,56 @@ func ReleaseNotesFlags(settings *app.ReleaseNotesSettings) []cli.Flag {
 			Usage:       "output file name. Omit to use standard output.",
 			Destination: &settings.Out,
 		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, use: md, rst, table or github",
+			Value:       formatter.FormatMarkdown,
+			Destination: &settings.Format,
+		},
+		&cli.IntFlag{
+			Name:        "wrap",
+			Usage:       "hard-wrap the output at the given column width, 0 disables wrapping",
+			Destination: &settings.Wrap,
+		},
+		&cli.BoolFlag{
+			Name:        "latest",
+			Usage:       "get release note from the most recent tag, overrides tag parameter",
+			Destination: &settings.Latest,
+		},
+		&cli.BoolFlag{
+			Name:        "since-stable",
+			Usage:       "get next release note since the last stable tag, prerelease tags are ignored",
+			Destination: &settings.SinceStable,
+		},
+		&cli.BoolFlag{
+			Name:        "breaking-only",
+			Usage:       "only include the breaking changes section",
+			Destination: &settings.BreakingOnly,
+		},
+		&cli.BoolFlag{
+			Name:        "warn-dropped",
+			Usage:       "log a warning for each commit not rendered because its type is not mapped to any section",
+			Destination: &settings.WarnDropped,
+		},
+		&cli.BoolFlag{
+			Name:        "stats",
+			Usage:       "add the files changed, insertions and deletions of the release",
+			Destination: &settings.Stats,
+		},
+		&cli.StringFlag{
+			Name:        "commit-url-template",
+			Usage:       "url used to link commits, {hash} is replaced by the commit hash; overrides config",
+			Destination: &settings.CommitURLTemplate,
+		},
 	}
 }
 
 func ReleaseNotesHandler(g app.GitSV, settings *app.ReleaseNotesSettings) cli.ActionFunc {
-	return func(_ *cli.Context) error {
+	return func(c *cli.Context) error {
+		if err := validateReleaseNoteFormat(settings.Format); err != nil {
+			return err
+		}
+
 		var (
 			commits   []sv.CommitLog
 			rnVersion *semver.Version
@@ -39,11 +88,28 @@ func ReleaseNotesHandler(g app.GitSV, settings *app.ReleaseNotesSettings) cli.Ac
 			err       error
 		)
 
+		if settings.Latest {
+			_, current, lerr := g.LatestReleaseRange()
+			if lerr != nil {
+				return lerr
+			}
+
+			settings.Tag = current
+		}
+
 		tagFlag := strings.TrimSpace(strings.ToLower(settings.Tag))
 
+		if settings.SinceStable {
+			if tagFlag != "next" {
+				return errCanNotUseSinceStable
+			}
+
+			g = stableGitSV(g)
+		}
+
 		if tagFlag == "next" {
 			// TODO: should generate release notes if version was not updated?
-			rnVersion, _, date, commits, err = getNextVersionInfo(g, g.CommitProcessor)
+			rnVersion, _, date, commits, err = getNextVersionInfo(g, g.CommitProcessor, "", "")
 		} else {
 			rnVersion, date, commits, err = getTagVersionInfo(g, settings.Tag)
 		}
@@ -52,20 +118,52 @@ func ReleaseNotesHandler(g app.GitSV, settings *app.ReleaseNotesSettings) cli.Ac
 			return err
 		}
 
-		releasenote := g.ReleasenotesProcessor.Create(rnVersion, settings.Tag, date, commits)
+		if settings.WarnDropped {
+			warnDroppedCommits(g.Config.ReleaseNotes, commits)
+		}
 
-		output, err := g.OutputFormatter.FormatReleaseNote(releasenote)
+		processor := g.ReleasenotesProcessor
+		if settings.CommitURLTemplate != "" {
+			rnCfg := g.Config.ReleaseNotes
+			rnCfg.CommitURLTemplate = settings.CommitURLTemplate
+			processor = sv.NewReleaseNoteProcessor(rnCfg)
+		}
+
+		releasenote := processor.Create(rnVersion, settings.Tag, date, commits)
+		if settings.BreakingOnly {
+			releasenote = releasenote.BreakingChangesOnly()
+		}
+
+		previousTag, currentTag, err := releaseTags(g, settings.Tag, tagFlag == "next")
+		if err != nil {
+			return err
+		}
+
+		releasenote.Compare = releaseCompare(g, previousTag, currentTag, rnVersion)
+
+		if settings.Stats {
+			stats, serr := g.RangeStats(previousTag, str(currentTag, "HEAD"))
+			if serr != nil {
+				return fmt.Errorf("error getting release stats: %w", serr)
+			}
+
+			releasenote.Stats = &stats
+		}
+
+		output, err := g.OutputFormatter.FormatReleaseNoteAs(settings.Format, releasenote)
 		if err != nil {
 			return fmt.Errorf("could not format release notes: %w", err)
 		}
 
+		output = formatter.Wrap(output, settings.Wrap)
+
 		if settings.Out == "" {
-			os.Stdout.WriteString(fmt.Sprintf("%s\n", output))
+			fmt.Fprintf(c.App.Writer, "%s\n", output)
 
 			return nil
 		}
 
-		w, err := os.Create(settings.Out)
+		w, err := os.Create(g.Path(settings.Out))
 		if err != nil {
 			return fmt.Errorf("could not write release notes: %w", err)
 		}
@@ -78,3 +176,50 @@ func ReleaseNotesHandler(g app.GitSV, settings *app.ReleaseNotesSettings) cli.Ac
 		return nil
 	}
 }
+
+func validateReleaseNoteFormat(format string) error {
+	switch format {
+	case formatter.FormatMarkdown, formatter.FormatRST, formatter.FormatTable, formatter.FormatGitHub:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s, expected: %s, %s, %s or %s", errUnknownOutputFormat, format,
+			formatter.FormatMarkdown, formatter.FormatRST, formatter.FormatTable, formatter.FormatGitHub)
+	}
+}
+
+// stableGitSV return a copy of g ignoring prerelease tags, e.g. to get the last stable tag.
+func stableGitSV(g app.GitSV) app.GitSV {
+	settings := *g.Settings
+	settings.ExcludePrereleases = true
+	g.Settings = &settings
+
+	return g
+}
+
+// releaseTags return the previous tag and the tag of the release, the tag is empty for the next version.
+func releaseTags(g app.GitSV, tag string, next bool) (string, string, error) {
+	if next {
+		return g.LastTag(), "", nil
+	}
+
+	previous, current, err := getTags(g, tag)
+	if err != nil {
+		return "", "", err
+	}
+
+	return previous, current.Name, nil
+}
+
+// releaseCompare return the compare link or range between the previous tag and the release tag,
+// the tag of the next version is built with the tag pattern. Empty if there is no previous tag.
+func releaseCompare(g app.GitSV, previous, current string, version *semver.Version) string {
+	if previous == "" {
+		return ""
+	}
+
+	if current == "" {
+		current = fmt.Sprintf(*g.Config.Tag.Pattern, version.Major(), version.Minor(), version.Patch())
+	}
+
+	return g.Config.ReleaseNotes.Compare(previous, current)
+}
@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/thegeeklab/git-sv/sv/formatter"
+	"github.com/thegeeklab/git-sv/templates"
+	"github.com/urfave/cli/v2"
+)
+
+func TestReleaseNotesHandlerCommitURLTemplate(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+	g.Config.ReleaseNotes.CommitURLTemplate = "https://config.example.com/{hash}"
+	g.ReleasenotesProcessor = sv.NewReleaseNoteProcessor(g.Config.ReleaseNotes)
+
+	gitCommit(t, "feat: first feature")
+
+	hash := strings.TrimSpace(git(t, "log", "-1", "--format=%h"))
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"config", []string{}, "([" + hash + "](https://config.example.com/" + hash + "))"},
+		{
+			"flag overrides config",
+			[]string{"--commit-url-template", "https://flag.example.com/commit/{hash}"},
+			"([" + hash + "](https://flag.example.com/commit/" + hash + "))",
+		},
+		{
+			"flag rst",
+			[]string{"--format", "rst", "--commit-url-template", "https://flag.example.com/commit/{hash}"},
+			"(`" + hash + " <https://flag.example.com/commit/" + hash + ">`__)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ReleaseNotesSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Flags: ReleaseNotesFlags(settings), Action: ReleaseNotesHandler(g, settings)}
+			if err := cliApp.Run(append([]string{"git-sv"}, tt.args...)); err != nil {
+				t.Fatalf("ReleaseNotesHandler() error = %v", err)
+			}
+
+			if got := out.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNotesHandlerGitHub(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+	g.Config.ReleaseNotes.CompareURLTemplate = "https://github.com/owner/repo/compare/{from}...{to}"
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "fix: some fix")
+
+	tests := []struct {
+		name    string
+		args    []string
+		compare string
+	}{
+		{"next version", []string{}, "https://github.com/owner/repo/compare/1.1.0...1.1.1"},
+		{"tag", []string{"--tag", "1.1.0"}, "https://github.com/owner/repo/compare/1.0.0...1.1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ReleaseNotesSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Flags: ReleaseNotesFlags(settings), Action: ReleaseNotesHandler(g, settings)}
+			if err := cliApp.Run(append([]string{"git-sv", "--format", "github"}, tt.args...)); err != nil {
+				t.Fatalf("ReleaseNotesHandler() error = %v", err)
+			}
+
+			got := out.String()
+
+			if strings.Contains(got, "## v") {
+				t.Errorf("ReleaseNotesHandler() = %s, want no version header", got)
+			}
+
+			for _, want := range []string{"**Contributors**: author", "**Full Changelog**: " + tt.compare} {
+				if !strings.Contains(got, want) {
+					t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseNotesHandlerStats(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+
+	if err := os.WriteFile("a.txt", []byte("one\ntwo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	git(t, "add", "a.txt")
+	gitCommit(t, "feat: add file")
+	git(t, "tag", "1.1.0")
+
+	if err := os.WriteFile("a.txt", []byte("one\n2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	git(t, "add", "a.txt")
+	gitCommit(t, "fix: change file")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"next version", []string{"--stats"}, "1 file changed, 1 insertion(+), 1 deletion(-)"},
+		{"tag", []string{"--stats", "--tag", "1.1.0"}, "1 file changed, 2 insertions(+), 0 deletions(-)"},
+		{"github", []string{"--stats", "--format", "github"}, "1 file changed, 1 insertion(+), 1 deletion(-)"},
+		{"without flag", []string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ReleaseNotesSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Flags: ReleaseNotesFlags(settings), Action: ReleaseNotesHandler(g, settings)}
+			if err := cliApp.Run(append([]string{"git-sv"}, tt.args...)); err != nil {
+				t.Fatalf("ReleaseNotesHandler() error = %v", err)
+			}
+
+			got := out.String()
+			if tt.want == "" && strings.Contains(got, "changed, ") {
+				t.Errorf("ReleaseNotesHandler() = %s, want no stats", got)
+			}
+
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNotesHandlerScopeFooter(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+	g.Config.CommitMessage.Scope.FooterKey = "Scope"
+	g.MessageProcessor = sv.NewMessageProcessor(g.Config.CommitMessage, g.Config.Branches)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature\n\nScope: api")
+	gitCommit(t, "fix(cli): first fix\n\nScope: api")
+
+	settings := &app.ReleaseNotesSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: ReleaseNotesFlags(settings), Action: ReleaseNotesHandler(g, settings)}
+	if err := cliApp.Run([]string{"git-sv"}); err != nil {
+		t.Fatalf("ReleaseNotesHandler() error = %v", err)
+	}
+
+	for _, want := range []string{"- **api:** second feature", "- **cli:** first fix"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", out.String(), want)
+		}
+	}
+}
+
+func TestReleaseNotesHandlerSinceStable(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: stable feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.1.0-rc.1")
+	gitCommit(t, "fix: first fix")
+	git(t, "tag", "1.1.0-rc.2")
+	gitCommit(t, "fix: second fix")
+
+	tests := []struct {
+		name        string
+		args        []string
+		want        []string
+		wantMissing []string
+	}{
+		{
+			"since last tag", []string{},
+			[]string{"second fix"},
+			[]string{"first feature", "first fix", "stable feature"},
+		},
+		{
+			"since stable", []string{"--since-stable"},
+			[]string{"## v1.1.0", "first feature", "first fix", "second fix"},
+			[]string{"stable feature", "rc."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ReleaseNotesSettings{}
+			got := runCommand(t, ReleaseNotesFlags(settings), ReleaseNotesHandler(g, settings), tt.args...)
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", got, want)
+				}
+			}
+
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(got, missing) {
+					t.Errorf("ReleaseNotesHandler() = %s, want not to contain %s", got, missing)
+				}
+			}
+		})
+	}
+
+	if g.Settings.ExcludePrereleases {
+		t.Errorf("ReleaseNotesHandler() changed exclude prereleases setting")
+	}
+
+	for _, args := range [][]string{{"--since-stable", "--tag", "1.0.0"}, {"--since-stable", "--latest"}} {
+		settings := &app.ReleaseNotesSettings{}
+
+		cliApp := &cli.App{Flags: ReleaseNotesFlags(settings), Action: ReleaseNotesHandler(g, settings)}
+		if err := cliApp.Run(append([]string{"git-sv"}, args...)); !errors.Is(err, errCanNotUseSinceStable) {
+			t.Errorf("ReleaseNotesHandler() error = %v, want %v", err, errCanNotUseSinceStable)
+		}
+	}
+}
+
+func TestReleaseNotesHandlerWrapTable(t *testing.T) {
+	g := newTestGitSV(t)
+	g.OutputFormatter = formatter.NewOutputFormatter(templates.New(""))
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: add a feature with a description long enough to exceed the wrap width of the table rows")
+
+	hash := strings.TrimSpace(git(t, "log", "-1", "--format=%h"))
+
+	settings := &app.ReleaseNotesSettings{}
+	args := []string{"--format", "table", "--wrap", "80"}
+	got := runCommand(t, ReleaseNotesFlags(settings), ReleaseNotesHandler(g, settings), args...)
+
+	want := "| feat |  | add a feature with a description long enough to exceed the wrap width of the table rows" +
+		" | author | " + hash + " |\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("ReleaseNotesHandler() = %s, want to contain %s", got, want)
+	}
+}
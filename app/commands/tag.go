@@ -5,7 +5,6 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/thegeeklab/git-sv/app"
-	"github.com/thegeeklab/git-sv/sv"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,6 +21,16 @@ func TagFlags(settings *app.TagSettings) []cli.Flag {
 			Usage:       "create local tag only",
 			Destination: &settings.Local,
 		},
+		&cli.BoolFlag{
+			Name:        "pre-release",
+			Usage:       "create the next pre-release version tag, e.g. 1.2.0-rc.1, with versioning.pre-release",
+			Destination: &settings.PreRelease,
+		},
+		&cli.StringFlag{
+			Name:        "commit",
+			Usage:       "create the tag at the given commit instead of HEAD",
+			Destination: &settings.Commit,
+		},
 	}
 }
 
@@ -29,16 +38,18 @@ func TagHandler(g app.GitSV, settings *app.TagSettings) cli.ActionFunc {
 	return func(_ *cli.Context) error {
 		lastTag := g.LastTag()
 
-		currentVer, err := sv.ToVersion(lastTag)
+		currentVer, err := currentVersion(g, lastTag)
 		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag: %w", lastTag, err)
+			return err
 		}
 
-		commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, ""))
+		commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, settings.Commit))
 		if err != nil {
 			return fmt.Errorf("error getting git log: %w", err)
 		}
 
+		warnInvalidBumpFooters(g.Config, commits)
+
 		nextVer, updated := g.CommitProcessor.NextVersion(currentVer, commits)
 		if !updated {
 			log.Info().Msgf("nothing to do: current version %s unchanged", currentVer)
@@ -46,7 +57,22 @@ func TagHandler(g app.GitSV, settings *app.TagSettings) cli.ActionFunc {
 			return nil
 		}
 
-		tagname, err := g.Tag(*nextVer, settings.Annotate, settings.Local)
+		if settings.PreRelease {
+			preRelease, updated, err := preReleaseVersion(g, currentVer, *nextVer, settings.Commit)
+			if err != nil {
+				return err
+			}
+
+			if !updated {
+				log.Info().Msgf("nothing to do: no releasable commit since %s", preRelease)
+
+				return nil
+			}
+
+			nextVer = preRelease
+		}
+
+		tagname, err := g.Tag(*nextVer, settings.Annotate, settings.Local, settings.Commit)
 		if err != nil {
 			return fmt.Errorf("error generating tag version: %s: %w", nextVer.String(), err)
 		}
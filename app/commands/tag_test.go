@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+)
+
+func TestTagHandlerSnapshot(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Settings.NextVersionSettings.Snapshot = true
+	g.Config.Versioning.SnapshotSuffix = "-SNAPSHOT"
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+
+	nextSettings := &app.NextVersionSettings{}
+
+	got := runCommand(t, NextVersionFlags(nextSettings), NextVersionHandler(g, nextSettings), "--snapshot")
+	if got != "1.1.0-SNAPSHOT\n" {
+		t.Fatalf("NextVersionHandler() = %q, want %q", got, "1.1.0-SNAPSHOT\n")
+	}
+
+	settings := &app.TagSettings{}
+	runCommand(t, TagFlags(settings), TagHandler(g, settings), "--local")
+
+	if tags := strings.Fields(git(t, "tag", "--list")); len(tags) != 2 || tags[1] != "1.1.0" {
+		t.Errorf("TagHandler() tags = %v, want [1.0.0 1.1.0] without snapshot suffix", tags)
+	}
+}
+
+func TestTagHandlerPreReleaseExcluded(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Settings.ExcludePrereleases = true
+	g.Config.Versioning.PreRelease = sv.PreReleaseConfig{Name: "rc"}
+	g.CommitProcessor = sv.NewSemVerCommitProcessor(g.Config.Versioning, g.Config.CommitMessage)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.2.0-rc.1")
+	gitCommit(t, "fix: first fix")
+
+	for range 2 {
+		settings := &app.TagSettings{}
+		runCommand(t, TagFlags(settings), TagHandler(g, settings), "--local", "--pre-release")
+	}
+
+	want := []string{"1.1.0", "1.2.0-rc.1", "1.2.0-rc.2"}
+	if tags := strings.Fields(git(t, "tag", "--list")); !reflect.DeepEqual(tags, want) {
+		t.Errorf("TagHandler() tags = %v, want %v", tags, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/rs/zerolog/log"
 	"github.com/thegeeklab/git-sv/app"
 	"github.com/thegeeklab/git-sv/sv"
 )
@@ -78,6 +79,16 @@ func str(value, defaultValue string) string {
 	return defaultValue
 }
 
+// versionString format version as major.minor.patch with the pre-release segment, if any.
+func versionString(version *semver.Version) string {
+	result := fmt.Sprintf("%d.%d.%d", version.Major(), version.Minor(), version.Patch())
+	if preRelease := version.Prerelease(); preRelease != "" {
+		result += "-" + preRelease
+	}
+
+	return result
+}
+
 func getTagVersionInfo(gsv app.GitSV, tag string) (*semver.Version, time.Time, []sv.CommitLog, error) {
 	tagVersion, _ := sv.ToVersion(tag)
 
@@ -91,28 +102,138 @@ func getTagVersionInfo(gsv app.GitSV, tag string) (*semver.Version, time.Time, [
 		return nil, time.Time{}, nil, fmt.Errorf("error getting git log from tag: %s: %w", tag, err)
 	}
 
-	return tagVersion, currentTag.Date, commits, nil
+	date := currentTag.Date
+	if date.IsZero() {
+		date = sv.LatestCommitDate(commits, time.Now())
+	}
+
+	return tagVersion, date, commits, nil
+}
+
+// currentVersion return the version of the last tag, if there is no tag the version is read
+// from the configured version file instead.
+func currentVersion(gsv app.GitSV, lastTag string) (*semver.Version, error) {
+	version, source := lastTag, "git tag"
+
+	if lastTag == "" {
+		fileVersion, err := gsv.FileVersion()
+		if err != nil {
+			return nil, err
+		}
+
+		version, source = fileVersion, "version file"
+	}
+
+	currentVer, err := sv.ToVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing version: %s from %s: %w", version, source, err)
+	}
+
+	return currentVer, nil
 }
 
 func getNextVersionInfo(
-	gsv app.GitSV, semverProcessor sv.CommitProcessor,
+	gsv app.GitSV, semverProcessor sv.CommitProcessor, baseTag, ref string,
 ) (*semver.Version, bool, time.Time, []sv.CommitLog, error) {
-	lastTag := gsv.LastTag()
+	lastTag := str(baseTag, gsv.LastTag())
 
-	commits, err := gsv.Log(app.NewLogRange(app.TagRange, lastTag, ""))
+	// only committed changes reachable from ref (HEAD if empty) are considered, also if HEAD is detached
+	commits, err := gsv.Log(app.NewLogRange(app.TagRange, lastTag, str(ref, "HEAD")))
 	if err != nil {
 		return nil, false, time.Time{}, nil, fmt.Errorf("error getting git log: %w", err)
 	}
 
-	currentVer, _ := sv.ToVersion(lastTag)
+	currentVer, err := currentVersion(gsv, lastTag)
+	if err != nil {
+		return nil, false, time.Time{}, nil, err
+	}
+
+	warnInvalidBumpFooters(gsv.Config, commits)
+
 	version, updated := semverProcessor.NextVersion(currentVer, commits)
 
 	return version, updated, time.Now(), commits, nil
 }
 
-func getCommitType(cfg *app.Config, p sv.MessageProcessor, input string) (string, error) {
+// preReleaseVersion return next as pre-release. The counter continues from the last pre-release tag of next,
+// also if pre-release tags are excluded from the last tag lookup. Return not updated if no releasable commit
+// was added up to ref since that pre-release tag.
+func preReleaseVersion(
+	g app.GitSV, current *semver.Version, next semver.Version, ref string,
+) (*semver.Version, bool, error) {
+	cfg := g.Config.Versioning.PreRelease
+	if cfg.Name == "" {
+		_, err := sv.PreReleaseVersion(current, next, cfg)
+
+		return nil, false, err
+	}
+
+	lastTag, err := g.LastPreReleaseTag(next, cfg.Name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if lastTag != "" {
+		commits, err := g.Log(app.NewLogRange(app.TagRange, lastTag, ref))
+		if err != nil {
+			return nil, false, fmt.Errorf("error getting git log: %w", err)
+		}
+
+		if current, err = sv.ToVersion(lastTag); err != nil {
+			return nil, false, err
+		}
+
+		if _, updated := g.CommitProcessor.NextVersion(current, commits); !updated {
+			return current, false, nil
+		}
+	}
+
+	version, err := sv.PreReleaseVersion(current, next, cfg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &version, true, nil
+}
+
+func warnDroppedCommits(cfg sv.ReleaseNotesConfig, commits []sv.CommitLog) {
+	for _, commit := range cfg.DroppedCommits(commits) {
+		log.Warn().
+			Str("hash", commit.Hash).
+			Str("type", commit.Message.Type).
+			Msg("commit dropped from release notes, type not mapped to any section")
+	}
+}
+
+// warnInvalidBumpFooters log a warning for each commit with an invalid bump footer value, the footer is
+// ignored and the commit is bumped according to its type.
+func warnInvalidBumpFooters(cfg *app.Config, commits []sv.CommitLog) {
+	if cfg.Versioning.BumpFooter == "" {
+		return
+	}
+
+	for _, commit := range commits {
+		value, exists := commit.Message.Metadata[cfg.Versioning.BumpFooter]
+		if _, valid := sv.ParseBump(value); exists && !valid {
+			log.Warn().
+				Str("hash", commit.Hash).
+				Str("value", value).
+				Msg("invalid bump footer ignored, expected major, minor, patch or none")
+		}
+	}
+}
+
+func warnMessage(p sv.MessageProcessor, message string) {
+	subject, _, _ := strings.Cut(message, "\n")
+
+	for _, warning := range p.Warnings(message) {
+		log.Warn().Str("subject", subject).Msg(warning)
+	}
+}
+
+func getCommitType(cfg *app.Config, p sv.MessageProcessor, input string, search bool) (string, error) {
 	if input == "" {
-		t, err := promptType(cfg.CommitMessage.Types)
+		t, err := promptType(cfg.CommitMessage.Types, search)
 
 		return t.Type, err
 	}
@@ -120,9 +241,11 @@ func getCommitType(cfg *app.Config, p sv.MessageProcessor, input string) (string
 	return input, p.ValidateType(input)
 }
 
-func getCommitScope(cfg *app.Config, p sv.MessageProcessor, input string, noScope bool) (string, error) {
+func getCommitScope(
+	cfg *app.Config, p sv.MessageProcessor, input string, noScope, search bool,
+) (string, error) {
 	if input == "" && !noScope {
-		return promptScope(cfg.CommitMessage.Scope.Values)
+		return promptScope(cfg.CommitMessage.Scope.Values, cfg.CommitMessage.Scope.Descriptions, search)
 	}
 
 	return input, p.ValidateScope(input)
@@ -166,7 +289,8 @@ func getCommitIssue(cfg *app.Config, p sv.MessageProcessor, branch string, noIss
 		return "", err
 	}
 
-	if cfg.CommitMessage.IssueFooterConfig().Key == "" || cfg.CommitMessage.Issue.Regex == "" {
+	if cfg.CommitMessage.IssueFooterConfig().Key == "" || len(cfg.CommitMessage.Issue.Regexes) == 0 ||
+		p.SkipIssueFooter(branch) {
 		return "", nil
 	}
 
@@ -174,7 +298,7 @@ func getCommitIssue(cfg *app.Config, p sv.MessageProcessor, branch string, noIss
 		return branchIssue, nil
 	}
 
-	return promptIssueID("issue id", cfg.CommitMessage.Issue.Regex, branchIssue)
+	return promptIssueID("issue id", strings.Join(cfg.CommitMessage.Issue.Regexes, "|"), branchIssue)
 }
 
 func getCommitBreakingChange(noBreaking bool, input string) (string, error) {
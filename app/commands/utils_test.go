@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_getNextVersionInfoBaseTag(t *testing.T) {
+	g := newTestGitSV(t)
+
+	gitCommit(t, "feat: first feature")
+	git(t, "tag", "1.0.0")
+	gitCommit(t, "feat: second feature")
+	git(t, "tag", "1.1.0")
+	gitCommit(t, "fix: some fix")
+
+	tests := []struct {
+		name        string
+		baseTag     string
+		want        string
+		wantCommits int
+	}{
+		{"last tag", "", "1.1.1", 1},
+		{"older base tag", "1.0.0", "1.1.0", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, updated, _, commits, err := getNextVersionInfo(g, g.CommitProcessor, tt.baseTag, "")
+			if err != nil {
+				t.Fatalf("getNextVersionInfo() error = %v", err)
+			}
+
+			if !updated || got.String() != tt.want {
+				t.Errorf("getNextVersionInfo() = %v, updated %v, want %v", got, updated, tt.want)
+			}
+
+			if len(commits) != tt.wantCommits {
+				t.Errorf("getNextVersionInfo() commits = %d, want %d", len(commits), tt.wantCommits)
+			}
+		})
+	}
+}
+
+func Test_getNextVersionInfoDetachedHead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T)
+		want        string
+		wantUpdated bool
+		wantCommits int
+	}{
+		{
+			"detached at tag",
+			func(t *testing.T) {
+				t.Helper()
+				gitCommit(t, "feat: first feature")
+				git(t, "tag", "1.0.0")
+				git(t, "checkout", "-q", "--detach", "1.0.0")
+			},
+			"1.0.0", false, 0,
+		},
+		{
+			"detached after tag",
+			func(t *testing.T) {
+				t.Helper()
+				gitCommit(t, "feat: first feature")
+				git(t, "tag", "1.0.0")
+				gitCommit(t, "fix: some fix")
+				git(t, "checkout", "-q", "--detach", "HEAD")
+			},
+			"1.0.1", true, 1,
+		},
+		{
+			"detached without tags",
+			func(t *testing.T) {
+				t.Helper()
+				gitCommit(t, "feat: first feature")
+				gitCommit(t, "fix: some fix")
+				git(t, "checkout", "-q", "--detach", "HEAD")
+			},
+			"0.1.0", true, 2,
+		},
+		{
+			"untracked and uncommitted changes",
+			func(t *testing.T) {
+				t.Helper()
+				gitCommit(t, "feat: first feature")
+				git(t, "tag", "1.0.0")
+				git(t, "checkout", "-q", "--detach", "HEAD")
+
+				if err := os.WriteFile("untracked.txt", []byte("noise"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			"1.0.0", false, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGitSV(t)
+			tt.setup(t)
+
+			if detached, err := g.IsDetached(); err != nil || !detached {
+				t.Fatalf("GitSV.IsDetached() = %v, error %v, want detached HEAD", detached, err)
+			}
+
+			got, updated, _, commits, err := getNextVersionInfo(g, g.CommitProcessor, "", "")
+			if err != nil {
+				t.Fatalf("getNextVersionInfo() error = %v", err)
+			}
+
+			if got.String() != tt.want || updated != tt.wantUpdated {
+				t.Errorf("getNextVersionInfo() = %v, updated %v, want %v, updated %v", got, updated, tt.want, tt.wantUpdated)
+			}
+
+			if len(commits) != tt.wantCommits {
+				t.Errorf("getNextVersionInfo() commits = %d, want %d", len(commits), tt.wantCommits)
+			}
+		})
+	}
+}
+
+func Test_warnDroppedCommits(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := log.Logger
+	log.Logger = zerolog.New(&buf)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	feat := sv.TestCommitlog("feat", map[string]string{}, "a")
+	feat.Hash = "abc123"
+	docs := sv.TestCommitlog("docs", map[string]string{}, "a")
+	docs.Hash = "def456"
+
+	warnDroppedCommits(app.GetDefault().ReleaseNotes, []sv.CommitLog{feat, docs})
+
+	want := `{"level":"warn","hash":"def456","type":"docs",` +
+		`"message":"commit dropped from release notes, type not mapped to any section"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("warnDroppedCommits() = %v, want %v", got, want)
+	}
+}
+
+func Test_warnInvalidBumpFooters(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := log.Logger
+	log.Logger = zerolog.New(&buf)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	cfg := app.GetDefault()
+	cfg.Versioning.BumpFooter = "version-bump"
+
+	valid := sv.TestCommitlog("feat", map[string]string{"version-bump": " Major"}, "a")
+	valid.Hash = "abc123"
+	invalid := sv.TestCommitlog("feat", map[string]string{"version-bump": "huge"}, "a")
+	invalid.Hash = "def456"
+	without := sv.TestCommitlog("feat", map[string]string{}, "a")
+
+	warnInvalidBumpFooters(cfg, []sv.CommitLog{valid, invalid, without})
+
+	want := `{"level":"warn","hash":"def456","value":"huge",` +
+		`"message":"invalid bump footer ignored, expected major, minor, patch or none"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("warnInvalidBumpFooters() = %v, want %v", got, want)
+	}
+}
+
+func Test_getCommitIssueSkipIssue(t *testing.T) {
+	cfg := app.GetDefault()
+	cfg.Branches.SkipIssue = []string{"release/.*"}
+	p := sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches)
+
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{"suppressed on matching branch", "release/JIRA-123", ""},
+		{"added on other branch", "feature/JIRA-123", "JIRA-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCommitIssue(cfg, p, tt.branch, true)
+			if err != nil {
+				t.Fatalf("getCommitIssue() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("getCommitIssue() = %v, want %v", got, tt.want)
+			}
+
+			_, _, footer := p.Format(sv.NewCommitMessage("fix", "", "something", "", got, ""))
+			if (footer != "") != (tt.want != "") {
+				t.Errorf("Format() footer = %q, want issue %q", footer, tt.want)
+			}
+		})
+	}
+}
+
+func newTestGitSV(t *testing.T) app.GitSV {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "author")
+	t.Setenv("GIT_AUTHOR_EMAIL", "author@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "committer")
+	t.Setenv("GIT_COMMITTER_EMAIL", "committer@example.com")
+
+	git(t, "init", "-q", "-b", "main")
+
+	cfg := app.GetDefault()
+
+	return app.GitSV{
+		Settings:              &app.Settings{},
+		Config:                cfg,
+		MessageProcessor:      sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+		CommitProcessor:       sv.NewSemVerCommitProcessor(cfg.Versioning, cfg.CommitMessage),
+		ReleasenotesProcessor: sv.NewReleaseNoteProcessor(cfg.ReleaseNotes),
+	}
+}
+
+// gitCommit create an empty commit, each commit is dated one minute after the previous one.
+func gitCommit(t *testing.T, message string) {
+	t.Helper()
+
+	count, _ := strconv.Atoi(strings.TrimSpace(git(t, "rev-list", "--all", "--count")))
+	date := time.Date(2020, 5, 1, 0, count, 0, 0, time.UTC).Format(time.RFC3339)
+
+	cmd := exec.Command("git", "commit", "-q", "--allow-empty", "-m", message)
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v: %s", err, out)
+	}
+}
+
+func git(t *testing.T, args ...string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", args...).CombinedOutput() //nolint:gosec
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+
+	return string(out)
+}
+
+// runCommand run the action with the flags and args, it fails the test on error and returns the output.
+func runCommand(t *testing.T, flags []cli.Flag, action cli.ActionFunc, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: flags, Action: action}
+	if err := cliApp.Run(append([]string{"git-sv"}, args...)); err != nil {
+		t.Fatalf("command %v error = %v", args, err)
+	}
+
+	return out.String()
+}
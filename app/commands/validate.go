@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/urfave/cli/v2"
+)
+
+const defaultMessagesDelimiter = "---"
+
+var (
+	errInvalidMessages  = errors.New("invalid commit messages")
+	errCanNotUseMessage = errors.New("cannot define message flag with messages-file flag")
+)
+
+func ValidateFlags(settings *app.ValidateSettings) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "message",
+			Aliases:     []string{"m"},
+			Usage:       "commit message to validate, read from stdin if neither message nor messages-file is set",
+			Destination: &settings.Message,
+		},
+		&cli.StringFlag{
+			Name:        "messages-file",
+			Usage:       "file containing the commit messages to validate",
+			Destination: &settings.MessagesFile,
+		},
+		&cli.StringFlag{
+			Name:        "delimiter",
+			Value:       defaultMessagesDelimiter,
+			Usage:       "line separating the commit messages in the messages file",
+			Destination: &settings.Delimiter,
+		},
+		&cli.IntFlag{
+			Name:        "max-errors",
+			Usage:       "stop after the given number of invalid messages, 0 validates all messages",
+			Destination: &settings.MaxErrors,
+		},
+	}
+}
+
+func ValidateHandler(g app.GitSV, settings *app.ValidateSettings) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if settings.MessagesFile == "" {
+			return validateMessage(c, g, settings)
+		}
+
+		if c.IsSet("message") {
+			return errCanNotUseMessage
+		}
+
+		content, err := readFile(settings.MessagesFile)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errReadCommitMessage, err.Error())
+		}
+
+		messages := splitMessages(content, settings.Delimiter)
+
+		invalid, checked := validateMessages(c.App.Writer, g, messages, settings.MaxErrors)
+		if invalid == 0 {
+			return nil
+		}
+
+		if checked < len(messages) {
+			return fmt.Errorf("%w: %d of %d, stopped after %d messages", errInvalidMessages, invalid, len(messages), checked)
+		}
+
+		return fmt.Errorf("%w: %d of %d", errInvalidMessages, invalid, len(messages))
+	}
+}
+
+// validateMessage validate a single message from the message flag or stdin and return the validation error.
+func validateMessage(c *cli.Context, g app.GitSV, settings *app.ValidateSettings) error {
+	message := settings.Message
+
+	if !c.IsSet("message") {
+		content, err := io.ReadAll(c.App.Reader)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errReadCommitMessage, err.Error())
+		}
+
+		message = string(content)
+	}
+
+	warnMessage(g.MessageProcessor, message)
+
+	return g.MessageProcessor.Validate(message)
+}
+
+// validateMessages validate each message independently and print the result per message, stops once
+// maxErrors invalid messages are found if maxErrors is greater than 0. Return the number of invalid
+// and of validated messages.
+func validateMessages(w io.Writer, g app.GitSV, messages []string, maxErrors int) (int, int) {
+	invalid := 0
+
+	for i, message := range messages {
+		subject, _, _ := strings.Cut(message, "\n")
+
+		warnMessage(g.MessageProcessor, message)
+
+		if err := g.MessageProcessor.Validate(message); err != nil {
+			invalid++
+
+			fmt.Fprintf(w, "%d: invalid: %s: %v\n", i+1, subject, err)
+
+			if maxErrors > 0 && invalid >= maxErrors {
+				return invalid, i + 1
+			}
+
+			continue
+		}
+
+		fmt.Fprintf(w, "%d: valid: %s\n", i+1, subject)
+	}
+
+	return invalid, len(messages)
+}
+
+// splitMessages split content at lines matching the delimiter, blank messages are dropped.
+func splitMessages(content, delimiter string) []string {
+	var (
+		messages []string
+		current  []string
+	)
+
+	flush := func() {
+		if message := strings.TrimSpace(strings.Join(current, "\n")); message != "" {
+			messages = append(messages, message)
+		}
+
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == delimiter {
+			flush()
+
+			continue
+		}
+
+		current = append(current, line)
+	}
+
+	flush()
+
+	return messages
+}
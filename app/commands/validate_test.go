@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_splitMessages(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		delimiter string
+		want      []string
+	}{
+		{"single message", "feat: add something\n", "---", []string{"feat: add something"}},
+		{
+			"multiple messages",
+			"feat: add something\n\nbody\n---\nfix: fix something\n---\n",
+			"---",
+			[]string{"feat: add something\n\nbody", "fix: fix something"},
+		},
+		{"custom delimiter", "feat: one\n%%\nfix: two", "%%", []string{"feat: one", "fix: two"}},
+		{"blank messages dropped", "---\n\n---\nfeat: one\r\n---", "---", []string{"feat: one"}},
+		{"empty content", "", "---", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitMessages(tt.content, tt.delimiter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitMessages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateHandler(t *testing.T) {
+	cfg := app.GetDefault()
+	g := app.GitSV{
+		Config:           cfg,
+		MessageProcessor: sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+	}
+
+	file := filepath.Join(t.TempDir(), "messages")
+	content := "feat: add something\n\nbody\n---\nnot conventional\n---\nfix(api): fix something\n---\nunknown: type\n"
+
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &app.ValidateSettings{}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{Writer: &out, Flags: ValidateFlags(settings), Action: ValidateHandler(g, settings)}
+
+	err := cliApp.Run([]string{"git-sv", "--messages-file", file})
+	if !errors.Is(err, errInvalidMessages) {
+		t.Errorf("ValidateHandler() error = %v, want %v", err, errInvalidMessages)
+	}
+
+	want := "1: valid: feat: add something\n" +
+		"2: invalid: not conventional: commit message not valid: subject [not conventional] not valid\n" +
+		"3: valid: fix(api): fix something\n" +
+		"4: invalid: unknown: type: commit message not valid: type must be one of " +
+		"[build, ci, chore, docs, feat, fix, perf, refactor, revert, style, test]\n"
+	if got := out.String(); got != want {
+		t.Errorf("ValidateHandler() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateHandlerExit(t *testing.T) {
+	cfg := app.GetDefault()
+	g := app.GitSV{
+		Config:           cfg,
+		MessageProcessor: sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		args      []string
+		wantErr   error
+		wantLines int
+	}{
+		{"clean", "feat: one\n---\nfix: two\n", nil, nil, 2},
+		{"one failure", "feat: one\n---\ninvalid\n---\nfix: two\n", nil, errInvalidMessages, 3},
+		{
+			"early stop", "invalid\n---\nfeat: one\n---\ninvalid\n---\ninvalid\n",
+			[]string{"--max-errors", "2"}, errInvalidMessages, 3,
+		},
+		{"max errors not reached", "feat: one\n---\ninvalid\n", []string{"--max-errors", "2"}, errInvalidMessages, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "messages")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			settings := &app.ValidateSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{Writer: &out, Flags: ValidateFlags(settings), Action: ValidateHandler(g, settings)}
+
+			err := cliApp.Run(append([]string{"git-sv", "--messages-file", file}, tt.args...))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateHandler() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := strings.Count(out.String(), "\n"); got != tt.wantLines {
+				t.Errorf("ValidateHandler() lines = %d, want %d: %s", got, tt.wantLines, out.String())
+			}
+		})
+	}
+}
+
+func TestValidateHandlerMessage(t *testing.T) {
+	cfg := app.GetDefault()
+	g := app.GitSV{
+		Config:           cfg,
+		MessageProcessor: sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+	}
+
+	tests := []struct {
+		name    string
+		stdin   string
+		args    []string
+		wantErr string
+	}{
+		{"valid message", "", []string{"--message", "feat: add something"}, ""},
+		{
+			"invalid message", "", []string{"-m", "not conventional"},
+			"commit message not valid: subject [not conventional] not valid",
+		},
+		{"valid stdin", "feat(api): add something\n\nbody\n", nil, ""},
+		{
+			"invalid stdin", "unknown: type\n", nil,
+			"commit message not valid: type must be one of " +
+				"[build, ci, chore, docs, feat, fix, perf, refactor, revert, style, test]",
+		},
+		{
+			"message and messages file", "", []string{"--message", "feat: add something", "--messages-file", "file"},
+			errCanNotUseMessage.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &app.ValidateSettings{}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{
+				Reader: strings.NewReader(tt.stdin), Writer: &out,
+				Flags: ValidateFlags(settings), Action: ValidateHandler(g, settings),
+			}
+
+			err := cliApp.Run(append([]string{"git-sv"}, tt.args...))
+			if (err == nil) != (tt.wantErr == "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("ValidateHandler() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if out.Len() != 0 {
+				t.Errorf("ValidateHandler() = %q, want no output", out.String())
+			}
+		})
+	}
+}
+
+func TestValidateHandlerWarnings(t *testing.T) {
+	var logs bytes.Buffer
+
+	logger := log.Logger
+	log.Logger = zerolog.New(&logs)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	cfg := app.GetDefault()
+	g := app.GitSV{
+		Config:           cfg,
+		MessageProcessor: sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches),
+	}
+
+	settings := &app.ValidateSettings{}
+	cliApp := &cli.App{Writer: &bytes.Buffer{}, Flags: ValidateFlags(settings), Action: ValidateHandler(g, settings)}
+
+	if err := cliApp.Run([]string{"git-sv", "-m", "feat: add something BREAKING-CHANGE"}); err != nil {
+		t.Fatalf("ValidateHandler() error = %v, want non-fatal warning", err)
+	}
+
+	if !strings.Contains(logs.String(), "breaking change in header is deprecated") {
+		t.Errorf("ValidateHandler() logs = %s, want deprecation warning", logs.String())
+	}
+}
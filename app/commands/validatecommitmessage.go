@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/thegeeklab/git-sv/app"
+	"github.com/thegeeklab/git-sv/sv"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,6 +17,7 @@ const laxFilePerm = 0o644
 var (
 	errReadCommitMessage = errors.New("failed to read commit message")
 	errAppendFooter      = errors.New("failed to append meta-informations on footer")
+	errIssueRequired     = errors.New("commit message requires an issue")
 )
 
 func ValidateCommitMessageFlags() []cli.Flag {
@@ -34,6 +37,14 @@ func ValidateCommitMessageFlags() []cli.Flag {
 			Required: true,
 			Usage:    "source of the commit message",
 		},
+		&cli.BoolFlag{
+			Name:  "print-only",
+			Usage: "print the enhanced commit message to stdout without modifying the file",
+		},
+		&cli.BoolFlag{
+			Name:  "no-enhance",
+			Usage: "only validate the commit message, do not append the issue footer",
+		},
 	}
 }
 
@@ -61,13 +72,29 @@ func ValidateCommitMessageHandler(g app.GitSV) cli.ActionFunc {
 			return fmt.Errorf("%w: %s", errReadCommitMessage, err.Error())
 		}
 
+		warnMessage(g.MessageProcessor, commitMessage)
+
 		if err := g.MessageProcessor.Validate(commitMessage); err != nil {
 			return fmt.Errorf("%w: %s", errReadCommitMessage, err.Error())
 		}
 
-		msg, err := g.MessageProcessor.Enhance(branch, commitMessage)
-		if err != nil {
-			log.Warn().Err(err).Msg("could not enhance commit message")
+		required := g.Config.CommitMessage.Issue.Required
+
+		msg := ""
+		if !c.Bool("no-enhance") {
+			if msg, err = g.MessageProcessor.Enhance(branch, commitMessage); err != nil {
+				log.Warn().Err(err).Msg("could not enhance commit message")
+
+				msg = ""
+			}
+		}
+
+		if required && !hasIssue(g.MessageProcessor, commitMessage+msg) {
+			return fmt.Errorf("%w: no issue footer found", errIssueRequired)
+		}
+
+		if c.Bool("print-only") {
+			fmt.Fprint(c.App.Writer, commitMessage+msg)
 
 			return nil
 		}
@@ -83,3 +110,12 @@ func ValidateCommitMessageHandler(g app.GitSV) cli.ActionFunc {
 		return nil
 	}
 }
+
+// hasIssue check if the final commit message, including an appended footer, references an issue.
+func hasIssue(p sv.MessageProcessor, message string) bool {
+	subject, body, _ := strings.Cut(message, "\n")
+
+	msg, err := p.Parse(subject, body)
+
+	return err == nil && msg.Issue() != ""
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestValidateCommitMessageHandlerPrintOnly(t *testing.T) {
+	g := newTestGitSV(t)
+	git(t, "checkout", "-q", "-b", "feature/JIRA-123")
+
+	dir := t.TempDir()
+	message := "feat: add something\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "COMMIT_EDITMSG"), []byte(message), laxFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{
+		Writer: &out,
+		Flags:  ValidateCommitMessageFlags(),
+		Action: ValidateCommitMessageHandler(g),
+	}
+
+	args := []string{"git-sv", "--path", dir, "--file", "COMMIT_EDITMSG", "--source", "message", "--print-only"}
+	if err := cliApp.Run(args); err != nil {
+		t.Fatalf("ValidateCommitMessageHandler() error = %v", err)
+	}
+
+	if want := message + "\njira: JIRA-123"; out.String() != want {
+		t.Errorf("ValidateCommitMessageHandler() output = %q, want %q", out.String(), want)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "COMMIT_EDITMSG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != message {
+		t.Errorf("ValidateCommitMessageHandler() file = %q, want %q", got, message)
+	}
+}
+
+func TestValidateCommitMessageHandlerPrintOnlyEnhanceError(t *testing.T) {
+	g := newTestGitSV(t)
+	git(t, "checkout", "-q", "-b", "feature/no-issue")
+
+	dir := t.TempDir()
+	message := "feat: add something\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "COMMIT_EDITMSG"), []byte(message), laxFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	cliApp := &cli.App{
+		Writer: &out,
+		Flags:  ValidateCommitMessageFlags(),
+		Action: ValidateCommitMessageHandler(g),
+	}
+
+	args := []string{"git-sv", "--path", dir, "--file", "COMMIT_EDITMSG", "--source", "message", "--print-only"}
+	if err := cliApp.Run(args); err != nil {
+		t.Fatalf("ValidateCommitMessageHandler() error = %v", err)
+	}
+
+	if out.String() != message {
+		t.Errorf("ValidateCommitMessageHandler() output = %q, want %q", out.String(), message)
+	}
+}
+
+func TestValidateCommitMessageHandlerNoEnhance(t *testing.T) {
+	g := newTestGitSV(t)
+	git(t, "checkout", "-q", "-b", "feature/JIRA-123")
+
+	tests := []struct {
+		name    string
+		message string
+		args    []string
+		wantOut string
+		wantErr error
+	}{
+		{"no enhance", "feat: add something\n", []string{"--no-enhance"}, "", nil},
+		{
+			"no enhance print only", "feat: add something\n",
+			[]string{"--no-enhance", "--print-only"}, "feat: add something\n", nil,
+		},
+		{"no enhance invalid message", "add something\n", []string{"--no-enhance"}, "", errReadCommitMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "COMMIT_EDITMSG")
+
+			if err := os.WriteFile(file, []byte(tt.message), laxFilePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+
+			cliApp := &cli.App{
+				Writer: &out,
+				Flags:  ValidateCommitMessageFlags(),
+				Action: ValidateCommitMessageHandler(g),
+			}
+
+			args := []string{"git-sv", "--path", dir, "--file", "COMMIT_EDITMSG", "--source", "message"}
+			if err := cliApp.Run(append(args, tt.args...)); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateCommitMessageHandler() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if out.String() != tt.wantOut {
+				t.Errorf("ValidateCommitMessageHandler() output = %q, want %q", out.String(), tt.wantOut)
+			}
+
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tt.message {
+				t.Errorf("ValidateCommitMessageHandler() file = %q, want %q", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMessageHandlerIssueRequired(t *testing.T) {
+	g := newTestGitSV(t)
+	git(t, "checkout", "-q", "-b", "feature/no-issue")
+
+	tests := []struct {
+		name     string
+		required bool
+		message  string
+		args     []string
+		wantErr  error
+	}{
+		{"required without issue", true, "feat: add something\n", nil, errIssueRequired},
+		{"required with issue footer", true, "feat: add something\n\njira: JIRA-123\n", nil, nil},
+		{"not required without issue", false, "feat: add something\n", nil, nil},
+		{"required no enhance without issue", true, "feat: add something\n", []string{"--no-enhance"}, errIssueRequired},
+		{
+			"required no enhance with issue footer", true, "feat: add something\n\njira: JIRA-123\n",
+			[]string{"--no-enhance"}, nil,
+		},
+		{"not required no enhance without issue", false, "feat: add something\n", []string{"--no-enhance"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Config.CommitMessage.Issue.Required = tt.required
+
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "COMMIT_EDITMSG"), []byte(tt.message), laxFilePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			cliApp := &cli.App{Flags: ValidateCommitMessageFlags(), Action: ValidateCommitMessageHandler(g)}
+
+			args := []string{"git-sv", "--path", dir, "--file", "COMMIT_EDITMSG", "--source", "message"}
+			if err := cliApp.Run(append(args, tt.args...)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateCommitMessageHandler() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMessageHandlerIssueRequiredBranch(t *testing.T) {
+	g := newTestGitSV(t)
+	g.Config.CommitMessage.Issue.Required = true
+
+	git(t, "checkout", "-q", "-b", "feature/JIRA-123")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{"issue appended from branch", nil, nil},
+		{"issue on branch but not appended", []string{"--no-enhance"}, errIssueRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			message := []byte("feat: add something\n")
+
+			if err := os.WriteFile(filepath.Join(dir, "COMMIT_EDITMSG"), message, laxFilePerm); err != nil {
+				t.Fatal(err)
+			}
+
+			cliApp := &cli.App{Flags: ValidateCommitMessageFlags(), Action: ValidateCommitMessageHandler(g)}
+
+			args := []string{"git-sv", "--path", dir, "--file", "COMMIT_EDITMSG", "--source", "message"}
+			if err := cliApp.Run(append(args, tt.args...)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateCommitMessageHandler() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
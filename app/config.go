@@ -1,10 +1,13 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 
 	"dario.cat/mergo"
 	"github.com/rs/zerolog/log"
@@ -13,26 +16,50 @@ import (
 )
 
 type Settings struct {
-	LogLevel string
+	LogLevel           string
+	Root               string
+	ExcludePrereleases bool
+	RemoteTags         bool
 
-	ChangelogSettings    ChangelogSettings
-	ReleaseNotesSettings ReleaseNotesSettings
-	CommitNotesSettings  CommitNotesSettings
-	CommitLogSettings    CommitLogSettings
-	TagSettings          TagSettings
+	ChangelogSettings      ChangelogSettings
+	ReleaseNotesSettings   ReleaseNotesSettings
+	CommitNotesSettings    CommitNotesSettings
+	CommitLogSettings      CommitLogSettings
+	TagSettings            TagSettings
+	NextVersionSettings    NextVersionSettings
+	CurrentVersionSettings CurrentVersionSettings
+	DiffSettings           DiffSettings
+	ValidateSettings       ValidateSettings
+	PlanSettings           PlanSettings
+	BumpSettings           BumpSettings
 }
 
 type ChangelogSettings struct {
-	Size    int
-	All     bool
-	AddNext bool
-	Strict  bool
-	Out     string
+	Size            int
+	All             bool
+	AddNext         bool
+	Strict          bool
+	Out             string
+	SplitDir        string
+	WarnDropped     bool
+	CollapsePatches bool
+	Ascending       bool
+	WithFooter      string
+	Format          string
 }
 
 type ReleaseNotesSettings struct {
-	Tag string
-	Out string
+	Tag          string
+	Out          string
+	BreakingOnly bool
+	WarnDropped  bool
+	Latest       bool
+	SinceStable  bool
+	Wrap         int
+	Format       string
+	Stats        bool
+	// CommitURLTemplate overrides release-notes.commit-url-template config.
+	CommitURLTemplate string
 }
 
 type CommitNotesSettings struct {
@@ -40,6 +67,7 @@ type CommitNotesSettings struct {
 	Start string
 	End   string
 	Out   string
+	Wrap  int
 }
 
 type CommitLogSettings struct {
@@ -47,35 +75,154 @@ type CommitLogSettings struct {
 	Range string
 	Start string
 	End   string
+	Stat  bool
+	Next  bool
+}
+
+type CurrentVersionSettings struct {
+	Format string
+}
+
+type NextVersionSettings struct {
+	Snapshot     bool
+	BaseTag      string
+	CountOnly    bool
+	Format       string
+	Previous     bool
+	RemoteBranch string
+	PreRelease   bool
+}
+
+type DiffSettings struct {
+	From      string
+	To        string
+	Inclusive bool
+	Out       string
+}
+
+type ValidateSettings struct {
+	MessagesFile string
+	Delimiter    string
+	MaxErrors    int
+	Message      string
+}
+
+type PlanSettings struct {
+	Format string
+}
+
+type BumpSettings struct {
+	File   string
+	Commit bool
 }
 
 type TagSettings struct {
-	Annotate bool
-	Local    bool
+	Annotate   bool
+	Local      bool
+	Commit     string
+	PreRelease bool
 }
 
 // Config cli yaml config.
 type Config struct {
-	LogLevel      string                 `yaml:"log-level"`
-	Versioning    sv.VersioningConfig    `yaml:"versioning"`
-	Tag           TagConfig              `yaml:"tag"`
-	ReleaseNotes  sv.ReleaseNotesConfig  `yaml:"release-notes"`
-	Branches      sv.BranchesConfig      `yaml:"branches"`
-	CommitMessage sv.CommitMessageConfig `yaml:"commit-message"`
+	LogLevel            string                 `yaml:"log-level"`
+	LogDateFormat       string                 `yaml:"log-date-format"`
+	DefaultOutputFormat string                 `yaml:"default-output-format"`
+	CommitPromptOrder   []string               `yaml:"commit-prompt-order,flow"`
+	Versioning          sv.VersioningConfig    `yaml:"versioning"`
+	Tag                 TagConfig              `yaml:"tag"`
+	ReleaseNotes        sv.ReleaseNotesConfig  `yaml:"release-notes"`
+	Branches            sv.BranchesConfig      `yaml:"branches"`
+	CommitMessage       sv.CommitMessageConfig `yaml:"commit-message"`
+	// IgnoreScopes commits with one of these scopes are ignored for versioning and notes, e.g. deps.
+	IgnoreScopes []string `yaml:"ignore-scopes,flow,omitempty"`
+	// Commands config overrides by command name, e.g. changelog, merged over the top-level config.
+	Commands map[string]Config `yaml:"commands,omitempty"`
 }
 
+// Commit prompt steps.
+const (
+	PromptStepType           = "type"
+	PromptStepScope          = "scope"
+	PromptStepDescription    = "description"
+	PromptStepBody           = "body"
+	PromptStepIssue          = "issue"
+	PromptStepBreakingChange = "breaking-change"
+)
+
+// DefaultPromptOrder return the default order of the commit prompt steps.
+func DefaultPromptOrder() []string {
+	return []string{
+		PromptStepType, PromptStepScope, PromptStepDescription, PromptStepBody, PromptStepIssue, PromptStepBreakingChange,
+	}
+}
+
+// AppendPrefix prefix of list entries used to append the list to the defaults instead of replacing them.
+const AppendPrefix = "+"
+
+// Supported output formats.
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
 // TagConfig tag preferences.
 type TagConfig struct {
 	Pattern *string `yaml:"pattern"`
 	Filter  *string `yaml:"filter"`
+	SortBy  string  `yaml:"sort-by"`
+	// ExcludePrereleases ignore tags with a prerelease segment, e.g. 1.2.0-rc.1.
+	ExcludePrereleases *bool `yaml:"exclude-prereleases"`
+	// VersionFile file containing the current version, used when no tag exists.
+	VersionFile string `yaml:"version-file"`
+	// MessageFormat printf format of annotated tag messages, with major, minor and patch version.
+	MessageFormat string `yaml:"message-format"`
+	// RemoteTags fetch the tags of all remotes into the local tags before looking up tags.
+	RemoteTags *bool `yaml:"remote-tags"`
+	// Sign create GPG signed tags, tags are always annotated if signed.
+	Sign *bool `yaml:"sign"`
+	// SigningKey key used to sign tags if sign is enabled, if empty the key of the committer identity is used.
+	SigningKey string `yaml:"signing-key,omitempty"`
 }
 
+// enabled return if an optional bool is set to true, the bool options are pointers so command
+// overrides can turn them off again.
+func enabled(value *bool) bool {
+	return value != nil && *value
+}
+
+// DefaultTagMessageFormat default annotated tag message format.
+const DefaultTagMessageFormat = "Version %d.%d.%d"
+
+// Supported tag sort keys.
+const (
+	TagSortCreatorDate   = "creatordate"
+	TagSortCommitterDate = "committerdate"
+)
+
+var (
+	errIssueRegexMissing    = errors.New("issue enhancement is enabled but commit-message.issue.regex is empty")
+	errDuplicatedCommitType = errors.New("commit type mapped by multiple release notes sections")
+	errBumpFooterMissing    = errors.New("versioning.bump-footer is not defined as commit-message.footer")
+	errInvalidScopeMinBump  = errors.New("invalid versioning.scope-min-bump value, the scope is ignored")
+	errInvalidMaxBump       = errors.New("invalid versioning.max-bump value, the bump is not capped")
+)
+
+// ConfigEnvVar environment variable pointing to a config file.
+const ConfigEnvVar = "GITSV_CONFIG"
+
+// NewConfig load the default config merged with the first config file found in configDir and the config
+// file of ConfigEnvVar, a relative configDir is resolved from the working directory.
 func NewConfig(configDir string, configFilenames []string) *Config {
-	workDir, _ := os.Getwd()
 	cfg := GetDefault()
 
+	if !filepath.IsAbs(configDir) {
+		workDir, _ := os.Getwd()
+		configDir = filepath.Join(workDir, configDir)
+	}
+
 	for _, filename := range configFilenames {
-		repoCfgFilepath := filepath.Join(workDir, configDir, filename)
+		repoCfgFilepath := filepath.Join(configDir, filename)
 		if repoCfg, err := readFile(repoCfgFilepath); err == nil {
 			if merr := merge(cfg, repoCfg); merr != nil {
 				log.Fatal().Err(merr).Msg("failed to merge repo config")
@@ -85,9 +232,102 @@ func NewConfig(configDir string, configFilenames []string) *Config {
 		}
 	}
 
+	if envCfgFilepath := os.Getenv(ConfigEnvVar); envCfgFilepath != "" {
+		envCfg, err := readFile(envCfgFilepath)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed to read config from %s", ConfigEnvVar)
+		}
+
+		if merr := merge(cfg, envCfg); merr != nil {
+			log.Fatal().Err(merr).Msgf("failed to merge config from %s", ConfigEnvVar)
+		}
+	}
+
 	return cfg
 }
 
+// Validate check config for misconfigurations, returns a list of diagnostics.
+func (c *Config) Validate() []error {
+	var diagnostics []error
+
+	if !c.Branches.DisableIssue && c.CommitMessage.IssueFooterConfig().Key != "" &&
+		len(c.CommitMessage.Issue.Regexes) == 0 {
+		diagnostics = append(diagnostics, errIssueRegexMissing)
+	}
+
+	if key := c.Versioning.BumpFooter; key != "" && c.CommitMessage.Footer[key].Key == "" {
+		diagnostics = append(diagnostics, fmt.Errorf("%w: %s", errBumpFooterMissing, key))
+	}
+
+	if bump := c.Versioning.MaxBump; bump != "" && !isBump(bump) {
+		diagnostics = append(diagnostics, fmt.Errorf(
+			"%w: %s, expected %s, %s or %s", errInvalidMaxBump, bump, sv.BumpMajor, sv.BumpMinor, sv.BumpPatch,
+		))
+	}
+
+	scopes := make([]string, 0, len(c.Versioning.ScopeMinBump))
+	for scope := range c.Versioning.ScopeMinBump {
+		scopes = append(scopes, scope)
+	}
+
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		if bump := c.Versioning.ScopeMinBump[scope]; !isBump(bump) {
+			diagnostics = append(diagnostics, fmt.Errorf(
+				"%w: %s: %s, expected %s, %s or %s",
+				errInvalidScopeMinBump, scope, bump, sv.BumpMajor, sv.BumpMinor, sv.BumpPatch,
+			))
+		}
+	}
+
+	duplicated := c.ReleaseNotes.DuplicatedCommitTypes()
+	commitTypes := make([]string, 0, len(duplicated))
+
+	for commitType := range duplicated {
+		commitTypes = append(commitTypes, commitType)
+	}
+
+	sort.Strings(commitTypes)
+
+	for _, commitType := range commitTypes {
+		diagnostics = append(diagnostics, fmt.Errorf(
+			"%w: %s in [%s], last section wins",
+			errDuplicatedCommitType, commitType, strings.Join(duplicated[commitType], ", "),
+		))
+	}
+
+	return diagnostics
+}
+
+// isBump check if value is a major, minor or patch bump.
+func isBump(value string) bool {
+	return value == sv.BumpMajor || value == sv.BumpMinor || value == sv.BumpPatch
+}
+
+// ForCommand return the config with the overrides of the given command merged over the top-level config,
+// the config itself is returned if the command has no overrides.
+func (c *Config) ForCommand(name string) (*Config, error) {
+	override, exists := c.Commands[name]
+	if !exists {
+		return c, nil
+	}
+
+	// merge into an empty config to not modify the maps shared with the top-level config
+	cfg := &Config{}
+	if err := merge(cfg, *c); err != nil {
+		return nil, err
+	}
+
+	if err := merge(cfg, override); err != nil {
+		return nil, err
+	}
+
+	cfg.Commands = nil
+
+	return cfg, nil
+}
+
 func readFile(filepath string) (Config, error) {
 	content, rerr := os.ReadFile(filepath)
 	if rerr != nil {
@@ -108,17 +348,27 @@ func GetDefault() *Config {
 	skipDetached := false
 	pattern := "%d.%d.%d"
 	filter := ""
+	excludePrereleases, remoteTags, sign := false, false, false
 
 	return &Config{
+		LogDateFormat:       "2006-01-02",
+		DefaultOutputFormat: OutputFormatText,
+		CommitPromptOrder:   DefaultPromptOrder(),
 		Versioning: sv.VersioningConfig{
-			UpdateMajor:   []string{},
-			UpdateMinor:   []string{"feat"},
-			UpdatePatch:   []string{"build", "ci", "chore", "docs", "fix", "perf", "refactor", "style", "test"},
-			IgnoreUnknown: false,
+			UpdateMajor:    []string{},
+			UpdateMinor:    []string{"feat"},
+			UpdatePatch:    []string{"build", "ci", "chore", "docs", "fix", "perf", "refactor", "style", "test"},
+			IgnoreUnknown:  false,
+			SnapshotSuffix: "-SNAPSHOT",
 		},
 		Tag: TagConfig{
-			Pattern: &pattern,
-			Filter:  &filter,
+			Pattern:            &pattern,
+			Filter:             &filter,
+			SortBy:             TagSortCreatorDate,
+			ExcludePrereleases: &excludePrereleases,
+			MessageFormat:      DefaultTagMessageFormat,
+			RemoteTags:         &remoteTags,
+			Sign:               &sign,
 		},
 		ReleaseNotes: sv.ReleaseNotesConfig{
 			Sections: []sv.ReleaseNotesSectionConfig{
@@ -140,7 +390,7 @@ func GetDefault() *Config {
 			Footer: map[string]sv.CommitMessageFooterConfig{
 				"issue": {Key: "jira", KeySynonyms: []string{"Jira", "JIRA"}},
 			},
-			Issue:          sv.CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+			Issue:          sv.CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
 			HeaderSelector: "",
 		},
 	}
@@ -153,6 +403,16 @@ func merge(dst *Config, src Config) error {
 type mergeTransformer struct{}
 
 func (t *mergeTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String {
+		return func(dst, src reflect.Value) error {
+			if dst.CanSet() && !src.IsNil() {
+				dst.Set(mergeStringSlice(dst, src))
+			}
+
+			return nil
+		}
+	}
+
 	if typ.Kind() == reflect.Slice {
 		return func(dst, src reflect.Value) error {
 			if dst.CanSet() && !src.IsNil() {
@@ -175,3 +435,41 @@ func (t *mergeTransformer) Transformer(typ reflect.Type) func(dst, src reflect.V
 
 	return nil
 }
+
+// mergeStringSlice replace dst with src, if any src entry is prefixed with AppendPrefix
+// the src entries are appended to dst instead, skipping duplicates.
+func mergeStringSlice(dst, src reflect.Value) reflect.Value {
+	values := make([]string, src.Len())
+	appendMode := false
+
+	for i := range values {
+		values[i] = src.Index(i).String()
+		if strings.HasPrefix(values[i], AppendPrefix) {
+			appendMode = true
+		}
+	}
+
+	if !appendMode {
+		return src
+	}
+
+	result := reflect.MakeSlice(src.Type(), 0, dst.Len()+len(values))
+	seen := make(map[string]struct{})
+
+	add := func(value string) {
+		if _, exists := seen[value]; !exists {
+			seen[value] = struct{}{}
+			result = reflect.Append(result, reflect.ValueOf(value).Convert(src.Type().Elem()))
+		}
+	}
+
+	for i := 0; i < dst.Len(); i++ {
+		add(dst.Index(i).String())
+	}
+
+	for _, value := range values {
+		add(strings.TrimPrefix(value, AppendPrefix))
+	}
+
+	return result
+}
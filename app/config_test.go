@@ -1,10 +1,14 @@
 package app
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/thegeeklab/git-sv/sv"
+	"gopkg.in/yaml.v3"
 )
 
 func Test_merge(t *testing.T) {
@@ -41,6 +45,20 @@ func Test_merge(t *testing.T) {
 			Config{Branches: sv.BranchesConfig{Skip: []string{"c", "d"}}},
 			false,
 		},
+		{
+			"append list",
+			Config{Branches: sv.BranchesConfig{Skip: []string{"master", "main"}}},
+			Config{Branches: sv.BranchesConfig{Skip: []string{"+release"}}},
+			Config{Branches: sv.BranchesConfig{Skip: []string{"master", "main", "release"}}},
+			false,
+		},
+		{
+			"append list with mixed entries and duplicates",
+			Config{Branches: sv.BranchesConfig{Skip: []string{"master", "main"}}},
+			Config{Branches: sv.BranchesConfig{Skip: []string{"+release", "main", "develop"}}},
+			Config{Branches: sv.BranchesConfig{Skip: []string{"master", "main", "release", "develop"}}},
+			false,
+		},
 		{
 			"overwrite list with empty",
 			Config{Branches: sv.BranchesConfig{Skip: []string{"a", "b"}}},
@@ -155,3 +173,233 @@ func Test_merge(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfig_envConfig(t *testing.T) {
+	workDir := t.TempDir()
+	envDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(workDir, ".gitsv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repoCfg := "log-level: debug\ntag:\n  pattern: \"v%d.%d.%d\"\n"
+	if err := os.WriteFile(filepath.Join(workDir, ".gitsv", "config.yml"), []byte(repoCfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	envCfgFilepath := filepath.Join(envDir, "gitsv.yml")
+	if err := os.WriteFile(envCfgFilepath, []byte("log-level: warn\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	chdir(t, workDir)
+	t.Setenv(ConfigEnvVar, envCfgFilepath)
+
+	cfg := NewConfig(".gitsv", []string{"config.yml"})
+
+	if cfg.LogLevel != "warn" {
+		t.Errorf("NewConfig() LogLevel = %v, want %v", cfg.LogLevel, "warn")
+	}
+
+	if *cfg.Tag.Pattern != "v%d.%d.%d" {
+		t.Errorf("NewConfig() Tag.Pattern = %v, want %v", *cfg.Tag.Pattern, "v%d.%d.%d")
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       func(cfg *Config)
+		wantDiags []error
+	}{
+		{"default config", func(_ *Config) {}, nil},
+		{
+			"missing issue regex",
+			func(cfg *Config) { cfg.CommitMessage.Issue.Regexes = nil },
+			[]error{errIssueRegexMissing},
+		},
+		{
+			"duplicated commit type in sections",
+			func(cfg *Config) {
+				cfg.ReleaseNotes.Sections = append(cfg.ReleaseNotes.Sections, sv.ReleaseNotesSectionConfig{
+					Name: "Changes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix", "feat"},
+				})
+			},
+			[]error{errDuplicatedCommitType, errDuplicatedCommitType},
+		},
+		{
+			"missing issue regex with disabled issue",
+			func(cfg *Config) {
+				cfg.CommitMessage.Issue.Regexes = nil
+				cfg.Branches.DisableIssue = true
+			},
+			nil,
+		},
+		{
+			"missing issue regex without issue footer",
+			func(cfg *Config) {
+				cfg.CommitMessage.Issue.Regexes = nil
+				cfg.CommitMessage.Footer = map[string]sv.CommitMessageFooterConfig{"issue": {}}
+			},
+			nil,
+		},
+		{
+			"missing bump footer",
+			func(cfg *Config) { cfg.Versioning.BumpFooter = "version-bump" },
+			[]error{errBumpFooterMissing},
+		},
+		{"invalid max bump", func(cfg *Config) { cfg.Versioning.MaxBump = "none" }, []error{errInvalidMaxBump}},
+		{"max bump", func(cfg *Config) { cfg.Versioning.MaxBump = "minor" }, nil},
+		{
+			"invalid scope min bump",
+			func(cfg *Config) {
+				cfg.Versioning.ScopeMinBump = map[string]string{"api": "Minor", "ui": "patch", "db": "none"}
+			},
+			[]error{errInvalidScopeMinBump, errInvalidScopeMinBump},
+		},
+		{
+			"bump footer",
+			func(cfg *Config) {
+				cfg.Versioning.BumpFooter = "version-bump"
+				cfg.CommitMessage.Footer["version-bump"] = sv.CommitMessageFooterConfig{Key: "Version-Bump"}
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := GetDefault()
+			tt.cfg(cfg)
+
+			got := cfg.Validate()
+			if len(got) != len(tt.wantDiags) {
+				t.Fatalf("Config.Validate() = %v, want %v", got, tt.wantDiags)
+			}
+
+			for i, want := range tt.wantDiags {
+				if !errors.Is(got[i], want) {
+					t.Errorf("Config.Validate() = %v, want %v", got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_ForCommand(t *testing.T) {
+	content := `commands:
+  changelog:
+    release-notes:
+      sections:
+        - name: Changes
+          section-type: commits
+          commit-types: [feat, fix]
+    commit-message:
+      footer:
+        refs: {key: Refs}
+`
+
+	var repoCfg Config
+	if err := yaml.Unmarshal([]byte(content), &repoCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := GetDefault()
+	if err := merge(cfg, repoCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	changelogCfg, err := cfg.ForCommand("changelog")
+	if err != nil {
+		t.Fatalf("Config.ForCommand() error = %v", err)
+	}
+
+	wantSections := []sv.ReleaseNotesSectionConfig{
+		{Name: "Changes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat", "fix"}},
+	}
+	if !reflect.DeepEqual(changelogCfg.ReleaseNotes.Sections, wantSections) {
+		t.Errorf("Config.ForCommand() sections = %v, want %v", changelogCfg.ReleaseNotes.Sections, wantSections)
+	}
+
+	if _, exists := changelogCfg.CommitMessage.Footer["refs"]; !exists ||
+		changelogCfg.CommitMessage.IssueFooterConfig().Key != "jira" {
+		t.Errorf("Config.ForCommand() footer = %v, want refs merged with issue", changelogCfg.CommitMessage.Footer)
+	}
+
+	if *changelogCfg.Tag.Pattern != *cfg.Tag.Pattern || changelogCfg.Commands != nil {
+		t.Errorf("Config.ForCommand() = %+v, want top-level settings without commands", changelogCfg)
+	}
+
+	releaseNotesCfg, err := cfg.ForCommand("release-notes")
+	if err != nil {
+		t.Fatalf("Config.ForCommand() error = %v", err)
+	}
+
+	if releaseNotesCfg != cfg || !reflect.DeepEqual(cfg.ReleaseNotes.Sections, GetDefault().ReleaseNotes.Sections) {
+		t.Errorf("Config.ForCommand() sections = %v, want default sections", releaseNotesCfg.ReleaseNotes.Sections)
+	}
+
+	if _, exists := cfg.CommitMessage.Footer["refs"]; exists {
+		t.Errorf("Config.ForCommand() modified top-level footer = %v", cfg.CommitMessage.Footer)
+	}
+}
+
+func TestConfig_ForCommandZeroValues(t *testing.T) {
+	content := `tag:
+  exclude-prereleases: true
+  remote-tags: true
+  sign: true
+  signing-key: releases@example.com
+commands:
+  tag:
+    tag:
+      exclude-prereleases: false
+      remote-tags: false
+      sign: false
+      signing-key: ""
+`
+
+	var repoCfg Config
+	if err := yaml.Unmarshal([]byte(content), &repoCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := GetDefault()
+	if err := merge(cfg, repoCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	tagCfg, err := cfg.ForCommand("tag")
+	if err != nil {
+		t.Fatalf("Config.ForCommand() error = %v", err)
+	}
+
+	if enabled(tagCfg.Tag.ExcludePrereleases) || enabled(tagCfg.Tag.RemoteTags) || enabled(tagCfg.Tag.Sign) {
+		t.Errorf("Config.ForCommand() tag = %+v, want bool options turned off", tagCfg.Tag)
+	}
+
+	if !enabled(cfg.Tag.ExcludePrereleases) || !enabled(cfg.Tag.RemoteTags) || !enabled(cfg.Tag.Sign) {
+		t.Errorf("Config.ForCommand() modified top-level tag = %+v", cfg.Tag)
+	}
+
+	// empty strings are not merged, an override can not clear a value.
+	if tagCfg.Tag.SigningKey != "releases@example.com" {
+		t.Errorf("Config.ForCommand() signing key = %q, want top-level key", tagCfg.Tag.SigningKey)
+	}
+}
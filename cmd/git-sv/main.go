@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -16,6 +17,12 @@ import (
 var (
 	BuildVersion = "devel"
 	BuildDate    = "00000000"
+
+	// tagCommands commands looking up tags, remote tags are fetched before running them if enabled.
+	tagCommands = []string{
+		"current-version", "next-version", "explain", "commit-log", "commit-notes", "release-notes",
+		"changelog", "diff", "plan", "tag", "bump",
+	}
 )
 
 func main() {
@@ -37,8 +44,24 @@ func main() {
 				Value:       "info",
 				Destination: &gsv.Settings.LogLevel,
 			},
+			&cli.StringFlag{
+				Name:        "root",
+				Aliases:     []string{"C"},
+				Usage:       "run git commands in the given repository path instead of the current directory",
+				Destination: &gsv.Settings.Root,
+			},
+			&cli.BoolFlag{
+				Name:        "exclude-prereleases",
+				Usage:       "ignore tags with a prerelease segment, e.g. 1.2.0-rc.1",
+				Destination: &gsv.Settings.ExcludePrereleases,
+			},
+			&cli.BoolFlag{
+				Name:        "remote-tags",
+				Usage:       "fetch the tags of all remotes into the local tags before looking up tags",
+				Destination: &gsv.Settings.RemoteTags,
+			},
 		},
-		Before: func(_ *cli.Context) error {
+		Before: func(c *cli.Context) error {
 			lvl, err := zerolog.ParseLevel(gsv.Settings.LogLevel)
 			if err != nil {
 				return err
@@ -46,6 +69,14 @@ func main() {
 
 			zerolog.SetGlobalLevel(lvl)
 
+			gsv = gsv.Load()
+
+			if !configValidateCommand(c.Args().Slice()) {
+				for _, diag := range gsv.Config.Validate() {
+					log.Warn().Err(diag).Msg("config validation")
+				}
+			}
+
 			return nil
 		},
 		Commands: []*cli.Command{
@@ -60,9 +91,23 @@ func main() {
 						Action: commands.ConfigDefaultHandler(),
 					},
 					{
-						Name:   "show",
-						Usage:  "show current config",
-						Action: commands.ConfigShowHandler(gsv.Config),
+						Name:  "show",
+						Usage: "show current config",
+						Action: action(&gsv, "", func(g app.GitSV) cli.ActionFunc {
+							return commands.ConfigShowHandler(g.Config)
+						}),
+					},
+					{
+						Name:  "scopes",
+						Usage: "list supported commit scopes",
+						Action: action(&gsv, "", func(g app.GitSV) cli.ActionFunc {
+							return commands.ConfigScopesHandler(g.Config)
+						}),
+					},
+					{
+						Name:   "validate",
+						Usage:  "validate current config",
+						Action: action(&gsv, "", commands.ConfigValidateHandler),
 					},
 				},
 			},
@@ -70,13 +115,24 @@ func main() {
 				Name:    "current-version",
 				Aliases: []string{"cv"},
 				Usage:   "get last released version from git",
-				Action:  commands.CurrentVersionHandler(gsv),
+				Action: action(&gsv, "current-version", func(g app.GitSV) cli.ActionFunc {
+					return commands.CurrentVersionHandler(g, &g.Settings.CurrentVersionSettings)
+				}),
+				Flags: commands.CurrentVersionFlags(&gsv.Settings.CurrentVersionSettings),
 			},
 			{
 				Name:    "next-version",
 				Aliases: []string{"nv"},
 				Usage:   "generate the next version based on git commit messages",
-				Action:  commands.NextVersionHandler(gsv),
+				Action: action(&gsv, "next-version", func(g app.GitSV) cli.ActionFunc {
+					return commands.NextVersionHandler(g, &g.Settings.NextVersionSettings)
+				}),
+				Flags: commands.NextVersionFlags(&gsv.Settings.NextVersionSettings),
+			},
+			{
+				Name:   "explain",
+				Usage:  "explain how the next version is computed from git commit messages",
+				Action: action(&gsv, "explain", commands.ExplainHandler),
 			},
 			{
 				Name:    "commit-log",
@@ -85,8 +141,10 @@ func main() {
 				Description: `The range filter is used based on git log filters, check https://git-scm.com/docs/git-log
 for more info. When flag range is "tag" and start is empty, last tag created will be used instead.
 When flag range is "date", if "end" is YYYY-MM-DD the range will be inclusive.`,
-				Action: commands.CommitLogHandler(gsv, &gsv.Settings.CommitLogSettings),
-				Flags:  commands.CommitLogFlags(&gsv.Settings.CommitLogSettings),
+				Action: action(&gsv, "commit-log", func(g app.GitSV) cli.ActionFunc {
+					return commands.CommitLogHandler(g, &g.Settings.CommitLogSettings)
+				}),
+				Flags: commands.CommitLogFlags(&gsv.Settings.CommitLogSettings),
 			},
 			{
 				Name:    "commit-notes",
@@ -95,42 +153,82 @@ When flag range is "date", if "end" is YYYY-MM-DD the range will be inclusive.`,
 				Description: `The range filter is used based on git log filters, check https://git-scm.com/docs/git-log
 for more info. When flag range is "tag" and start is empty, last tag created will be used instead.
 When flag range is "date", if "end" is YYYY-MM-DD the range will be inclusive.`,
-				Action: commands.CommitNotesHandler(gsv, &gsv.Settings.CommitNotesSettings),
-				Flags:  commands.CommitNotesFlags(&gsv.Settings.CommitNotesSettings),
+				Action: action(&gsv, "commit-notes", func(g app.GitSV) cli.ActionFunc {
+					return commands.CommitNotesHandler(g, &g.Settings.CommitNotesSettings)
+				}),
+				Flags: commands.CommitNotesFlags(&gsv.Settings.CommitNotesSettings),
 			},
 			{
 				Name:    "release-notes",
 				Aliases: []string{"rn"},
 				Usage:   "generate release notes",
-				Action:  commands.ReleaseNotesHandler(gsv, &gsv.Settings.ReleaseNotesSettings),
-				Flags:   commands.ReleaseNotesFlags(&gsv.Settings.ReleaseNotesSettings),
+				Action: action(&gsv, "release-notes", func(g app.GitSV) cli.ActionFunc {
+					return commands.ReleaseNotesHandler(g, &g.Settings.ReleaseNotesSettings)
+				}),
+				Flags: commands.ReleaseNotesFlags(&gsv.Settings.ReleaseNotesSettings),
 			},
 			{
 				Name:    "changelog",
 				Aliases: []string{"cgl"},
 				Usage:   "generate changelog",
-				Action:  commands.ChangelogHandler(gsv, &gsv.Settings.ChangelogSettings),
-				Flags:   commands.ChangelogFlags(&gsv.Settings.ChangelogSettings),
+				Action: action(&gsv, "changelog", func(g app.GitSV) cli.ActionFunc {
+					return commands.ChangelogHandler(g, &g.Settings.ChangelogSettings)
+				}),
+				Flags: commands.ChangelogFlags(&gsv.Settings.ChangelogSettings),
+			},
+			{
+				Name:  "diff",
+				Usage: "generate release notes for all tags after a version up to another version",
+				Action: action(&gsv, "diff", func(g app.GitSV) cli.ActionFunc {
+					return commands.DiffHandler(g, &g.Settings.DiffSettings)
+				}),
+				Flags: commands.DiffFlags(&gsv.Settings.DiffSettings),
+			},
+			{
+				Name:  "plan",
+				Usage: "print the next version, tag, commits and release notes of the next release as json",
+				Action: action(&gsv, "plan", func(g app.GitSV) cli.ActionFunc {
+					return commands.PlanHandler(g, &g.Settings.PlanSettings)
+				}),
+				Flags: commands.PlanFlags(&gsv.Settings.PlanSettings),
 			},
 			{
 				Name:    "tag",
 				Aliases: []string{"tg"},
 				Usage:   "generate tag with version based on git commit messages",
-				Action:  commands.TagHandler(gsv, &gsv.Settings.TagSettings),
-				Flags:   commands.TagFlags(&gsv.Settings.TagSettings),
+				Action: action(&gsv, "tag", func(g app.GitSV) cli.ActionFunc {
+					return commands.TagHandler(g, &g.Settings.TagSettings)
+				}),
+				Flags: commands.TagFlags(&gsv.Settings.TagSettings),
+			},
+			{
+				Name:  "bump",
+				Usage: "write the next version to a version file and optionally commit it",
+				Action: action(&gsv, "bump", func(g app.GitSV) cli.ActionFunc {
+					return commands.BumpHandler(g, &g.Settings.BumpSettings)
+				}),
+				Flags: commands.BumpFlags(&gsv.Settings.BumpSettings),
 			},
 			{
 				Name:    "commit",
 				Aliases: []string{"cmt"},
 				Usage:   "execute git commit with conventional commit message helper",
-				Action:  commands.CommitHandler(gsv),
+				Action:  action(&gsv, "commit", commands.CommitHandler),
 				Flags:   commands.CommitFlags(),
 			},
+			{
+				Name:  "validate",
+				Usage: "validate a commit message or a batch of commit messages, e.g. of a patch series",
+				Action: action(&gsv, "validate", func(g app.GitSV) cli.ActionFunc {
+					return commands.ValidateHandler(g, &g.Settings.ValidateSettings)
+				}),
+				Flags: commands.ValidateFlags(&gsv.Settings.ValidateSettings),
+			},
 			{
 				Name:    "validate-commit-message",
 				Aliases: []string{"vcm"},
 				Usage:   "use as prepare-commit-message hook to validate and enhance commit message",
-				Action:  commands.ValidateCommitMessageHandler(gsv),
+				Action:  action(&gsv, "validate-commit-message", commands.ValidateCommitMessageHandler),
 				Flags:   commands.ValidateCommitMessageFlags(),
 			},
 		},
@@ -140,3 +238,28 @@ When flag range is "date", if "end" is YYYY-MM-DD the range will be inclusive.`,
 		log.Fatal().Err(err).Msg("Execution error")
 	}
 }
+
+// action create the handler of a command once the config is loaded, using the config overrides of the
+// command name. The config is loaded after the flags are parsed to resolve it from the root flag.
+func action(gsv *app.GitSV, name string, handler func(g app.GitSV) cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		g, err := gsv.ForCommand(name)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(tagCommands, name) {
+			if err := g.FetchRemoteTags(); err != nil {
+				return fmt.Errorf("could not fetch remote tags: %w", err)
+			}
+		}
+
+		return handler(g)(c)
+	}
+}
+
+// configValidateCommand return true if args run the config validate command, it reports the config
+// diagnostics itself, so they are not logged before.
+func configValidateCommand(args []string) bool {
+	return len(args) >= 2 && (args[0] == "config" || args[0] == "cfg") && args[1] == "validate"
+}
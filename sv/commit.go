@@ -1,6 +1,13 @@
 package sv
 
-import "github.com/Masterminds/semver/v3"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
 
 type versionType int
 
@@ -13,13 +20,50 @@ const (
 
 // CommitLog description of a single commit log.
 type CommitLog struct {
-	Date       string        `json:"date,omitempty"`
-	Timestamp  int           `json:"timestamp,omitempty"`
-	AuthorName string        `json:"authorName,omitempty"`
-	Hash       string        `json:"hash,omitempty"`
-	Message    CommitMessage `json:"message,omitempty"`
+	Date        string        `json:"date,omitempty"`
+	Timestamp   int           `json:"timestamp,omitempty"`
+	AuthorName  string        `json:"authorName,omitempty"`
+	AuthorEmail string        `json:"authorEmail,omitempty"`
+	Hash        string        `json:"hash,omitempty"`
+	Message     CommitMessage `json:"message,omitempty"`
+	Stats       *CommitStats  `json:"stats,omitempty"`
+}
+
+// CommitStats file change statistics of a single commit.
+type CommitStats struct {
+	FilesChanged int `json:"filesChanged"`
+	Insertions   int `json:"insertions"`
+	Deletions    int `json:"deletions"`
 }
 
+// String format the statistics like git diff --shortstat, e.g. "1 file changed, 2 insertions(+), 0 deletions(-)".
+func (s CommitStats) String() string {
+	return fmt.Sprintf("%s changed, %s(+), %s(-)",
+		plural(s.FilesChanged, "file"), plural(s.Insertions, "insertion"), plural(s.Deletions, "deletion"))
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// LatestCommitDate return the date of the newest commit, or fallback if there are no commits.
+func LatestCommitDate(commits []CommitLog, fallback time.Time) time.Time {
+	if len(commits) == 0 {
+		return fallback
+	}
+
+	return time.Unix(int64(commits[0].Timestamp), 0)
+}
+
+// DefaultPreReleasePattern default printf format of the pre-release counter.
+const DefaultPreReleasePattern = "%d"
+
+var errPreReleaseNotConfigured = errors.New("pre-release is not configured, check versioning.pre-release.name")
+
 // IsValidVersion return true when a version is valid.
 func IsValidVersion(value string) bool {
 	_, err := semver.NewVersion(value)
@@ -27,6 +71,13 @@ func IsValidVersion(value string) bool {
 	return err == nil
 }
 
+// IsPrereleaseVersion check if value is a valid semantic version with a prerelease segment.
+func IsPrereleaseVersion(value string) bool {
+	version, err := semver.NewVersion(value)
+
+	return err == nil && version.Prerelease() != ""
+}
+
 // ToVersion parse string to semver.Version.
 func ToVersion(value string) (*semver.Version, error) {
 	version := value
@@ -37,9 +88,54 @@ func ToVersion(value string) (*semver.Version, error) {
 	return semver.NewVersion(version)
 }
 
+// SnapshotVersion format version as major.minor.patch with the snapshot suffix appended.
+func SnapshotVersion(version semver.Version, suffix string) string {
+	return fmt.Sprintf("%d.%d.%d%s", version.Major(), version.Minor(), version.Patch(), suffix)
+}
+
+// constants for bump type.
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+	BumpNone  = "none"
+)
+
+// BumpType compare two versions and return the bump type between them, empty if none.
+func BumpType(previous, current *semver.Version) string {
+	if previous == nil || current == nil || !current.GreaterThan(previous) {
+		return ""
+	}
+
+	switch {
+	case current.Major() != previous.Major():
+		return BumpMajor
+	case current.Minor() != previous.Minor():
+		return BumpMinor
+	case current.Patch() != previous.Patch():
+		return BumpPatch
+	default:
+		return ""
+	}
+}
+
+// BumpCounts number of releasable commits by bump level.
+type BumpCounts struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// Releasable return the total of releasable commits.
+func (c BumpCounts) Releasable() int {
+	return c.Major + c.Minor + c.Patch
+}
+
 // CommitProcessor interface.
 type CommitProcessor interface {
 	NextVersion(version *semver.Version, commits []CommitLog) (*semver.Version, bool)
+	CountBumps(commits []CommitLog) BumpCounts
+	Bump(commit CommitLog) (string, string)
 }
 
 // SemVerCommitProcessor process versions using commit log.
@@ -49,24 +145,67 @@ type SemVerCommitProcessor struct {
 	PatchVersionTypes         map[string]struct{}
 	KnownTypes                []string
 	IncludeUnknownTypeAsPatch bool
+	MinCommits                int
+	ScopeMinVersionTypes      map[string]versionType
+	// MaxVersionType caps the bump of every commit, none disables the cap.
+	MaxVersionType versionType
+	// NonConventional enables NonConventionalVersionType for commits without conventional type,
+	// if false they are handled as unknown types.
+	NonConventional            bool
+	NonConventionalVersionType versionType
+	// PreRelease enables pre-release aware bumps, a bump already included in a pre-release version
+	// is not applied again, e.g. a minor bump of 1.2.0-rc.1 releases 1.2.0.
+	PreRelease bool
+	// BumpMetadataKey metadata key of the footer overriding the type based bump of a commit.
+	BumpMetadataKey string
 }
 
 // VersioningConfig versioning preferences.
 type VersioningConfig struct {
-	UpdateMajor   []string `yaml:"update-major,flow"`
-	UpdateMinor   []string `yaml:"update-minor,flow"`
-	UpdatePatch   []string `yaml:"update-patch,flow"`
-	IgnoreUnknown bool     `yaml:"ignore-unknown"`
+	UpdateMajor    []string `yaml:"update-major,flow"`
+	UpdateMinor    []string `yaml:"update-minor,flow"`
+	UpdatePatch    []string `yaml:"update-patch,flow"`
+	IgnoreUnknown  bool     `yaml:"ignore-unknown"`
+	SnapshotSuffix string   `yaml:"snapshot-suffix"`
+	// MinCommits minimum number of releasable commits required to update the version.
+	MinCommits int `yaml:"min-commits,omitempty"`
+	// ScopeMinBump minimum bump (major, minor or patch) of commits by scope.
+	ScopeMinBump map[string]string `yaml:"scope-min-bump,omitempty"`
+	// MaxBump maximum bump (major, minor or patch) of any commit, including breaking changes.
+	MaxBump string `yaml:"max-bump,omitempty"`
+	// NonConventionalBump bump (major, minor, patch or none) of commits without conventional type,
+	// if empty they are handled as unknown types according to IgnoreUnknown.
+	NonConventionalBump string `yaml:"non-conventional-bump,omitempty"`
+	// PreRelease pre-release versions preferences, e.g. 1.2.0-rc.1.
+	PreRelease PreReleaseConfig `yaml:"pre-release,omitempty"`
+	// BumpFooter metadata key of a commit-message footer overriding the type based bump (major, minor, patch
+	// or none) of a commit, e.g. "version-bump" for a footer {key: Version-Bump}, empty disables it.
+	BumpFooter string `yaml:"bump-footer,omitempty"`
+}
+
+// PreReleaseConfig pre-release versions preferences.
+type PreReleaseConfig struct {
+	// Name identifier of pre-release versions, e.g. "rc" for 1.2.0-rc.1, empty disables pre-releases.
+	Name string `yaml:"name"`
+	// Pattern printf format of the pre-release counter, DefaultPreReleasePattern if empty.
+	Pattern string `yaml:"pattern"`
 }
 
 // NewSemVerCommitProcessor SemanticVersionCommitProcessorImpl constructor.
 func NewSemVerCommitProcessor(vcfg VersioningConfig, mcfg CommitMessageConfig) *SemVerCommitProcessor {
 	return &SemVerCommitProcessor{
-		IncludeUnknownTypeAsPatch: !vcfg.IgnoreUnknown,
-		MajorVersionTypes:         toMap(vcfg.UpdateMajor),
-		MinorVersionTypes:         toMap(vcfg.UpdateMinor),
-		PatchVersionTypes:         toMap(vcfg.UpdatePatch),
-		KnownTypes:                mcfg.Types,
+		IncludeUnknownTypeAsPatch:  !vcfg.IgnoreUnknown,
+		MajorVersionTypes:          toMap(vcfg.UpdateMajor),
+		MinorVersionTypes:          toMap(vcfg.UpdateMinor),
+		PatchVersionTypes:          toMap(vcfg.UpdatePatch),
+		KnownTypes:                 mcfg.Types,
+		MinCommits:                 vcfg.MinCommits,
+		ScopeMinVersionTypes:       toVersionTypeMap(vcfg.ScopeMinBump),
+		MaxVersionType:             toVersionType(vcfg.MaxBump),
+		NonConventional:            isValidBump(vcfg.NonConventionalBump),
+		NonConventionalVersionType: toVersionType(vcfg.NonConventionalBump),
+		PreRelease:                 vcfg.PreRelease.Name != "",
+		BumpMetadataKey:            vcfg.BumpFooter,
 	}
 }
 
@@ -75,10 +214,21 @@ func (p SemVerCommitProcessor) NextVersion(
 	version *semver.Version, commits []CommitLog,
 ) (*semver.Version, bool) {
 	versionToUpdate := none
+	releasable := 0
+
 	for _, commit := range commits {
-		if v := p.versionTypeToUpdate(commit); v > versionToUpdate {
+		v, _ := p.versionTypeToUpdate(commit)
+		if v > versionToUpdate {
 			versionToUpdate = v
 		}
+
+		if v != none {
+			releasable++
+		}
+	}
+
+	if releasable < p.MinCommits {
+		versionToUpdate = none
 	}
 
 	updated := versionToUpdate != none
@@ -87,10 +237,79 @@ func (p SemVerCommitProcessor) NextVersion(
 	}
 
 	newVersion := updateVersion(*version, versionToUpdate)
+	if p.PreRelease && version.Prerelease() != "" {
+		newVersion = updatePreReleaseVersion(*version, versionToUpdate)
+	}
 
 	return &newVersion, updated
 }
 
+// PreReleaseVersion return next as pre-release, e.g. 1.2.0-rc.1. The counter is incremented if current is
+// already a pre-release of next with the same name, otherwise it starts at 1.
+func PreReleaseVersion(current *semver.Version, next semver.Version, cfg PreReleaseConfig) (semver.Version, error) {
+	if cfg.Name == "" {
+		return next, errPreReleaseNotConfigured
+	}
+
+	pattern := cfg.Pattern
+	if pattern == "" {
+		pattern = DefaultPreReleasePattern
+	}
+
+	counter := 1
+
+	if current != nil && current.Major() == next.Major() && current.Minor() == next.Minor() &&
+		current.Patch() == next.Patch() {
+		if suffix, found := strings.CutPrefix(current.Prerelease(), cfg.Name+"."); found {
+			var last int
+			if _, err := fmt.Sscanf(suffix, pattern, &last); err == nil {
+				counter = last + 1
+			}
+		}
+	}
+
+	release := semver.New(next.Major(), next.Minor(), next.Patch(), "", "")
+
+	return release.SetPrerelease(cfg.Name + "." + fmt.Sprintf(pattern, counter))
+}
+
+// CountBumps count releasable commits by bump level.
+func (p SemVerCommitProcessor) CountBumps(commits []CommitLog) BumpCounts {
+	var counts BumpCounts
+
+	for _, commit := range commits {
+		switch v, _ := p.versionTypeToUpdate(commit); v {
+		case major:
+			counts.Major++
+		case minor:
+			counts.Minor++
+		case patch:
+			counts.Patch++
+		case none:
+		}
+	}
+
+	return counts
+}
+
+// Bump return the bump type caused by a single commit, empty if the commit is not releasable, and the
+// rule which decided it, e.g. "type feat" or "scope api minimum".
+func (p SemVerCommitProcessor) Bump(commit CommitLog) (string, string) {
+	v, reason := p.versionTypeToUpdate(commit)
+
+	switch v {
+	case major:
+		return BumpMajor, reason
+	case minor:
+		return BumpMinor, reason
+	case patch:
+		return BumpPatch, reason
+	case none:
+	}
+
+	return "", reason
+}
+
 func updateVersion(version semver.Version, versionToUpdate versionType) semver.Version {
 	switch versionToUpdate {
 	case major:
@@ -104,28 +323,131 @@ func updateVersion(version semver.Version, versionToUpdate versionType) semver.V
 	}
 }
 
-func (p SemVerCommitProcessor) versionTypeToUpdate(commit CommitLog) versionType {
+// updatePreReleaseVersion update a pre-release version, bumps included in the pre-release, e.g. patch and
+// minor for 1.2.0-rc.1, release the version without pre-release, other bumps are applied to it.
+func updatePreReleaseVersion(version semver.Version, versionToUpdate versionType) semver.Version {
+	release := semver.New(version.Major(), version.Minor(), version.Patch(), "", "")
+
+	switch {
+	case versionToUpdate == none:
+		return version
+	case versionToUpdate == patch,
+		versionToUpdate == minor && version.Patch() == 0,
+		versionToUpdate == major && version.Minor() == 0 && version.Patch() == 0:
+		return *release
+	default:
+		return updateVersion(*release, versionToUpdate)
+	}
+}
+
+// versionTypeToUpdate return the bump of a commit and the rule which decided it, the type based bump is
+// replaced by the bump footer, raised to the scope minimum and capped by the max bump in this order.
+func (p SemVerCommitProcessor) versionTypeToUpdate(commit CommitLog) (versionType, string) {
+	v, reason := p.typeVersionTypeToUpdate(commit)
+
+	if bump, exists := p.bumpOverride(commit); exists {
+		v, reason = bump, p.BumpMetadataKey+" footer"
+	}
+
+	if minimum, exists := p.ScopeMinVersionTypes[commit.Message.Scope]; exists && minimum > v {
+		v, reason = minimum, fmt.Sprintf("scope %s minimum", commit.Message.Scope)
+	}
+
+	if p.MaxVersionType != none && v > p.MaxVersionType {
+		return p.MaxVersionType, "max-bump caps " + reason
+	}
+
+	return v, reason
+}
+
+// bumpOverride return the bump of the bump footer of a commit, invalid values are ignored.
+func (p SemVerCommitProcessor) bumpOverride(commit CommitLog) (versionType, bool) {
+	if p.BumpMetadataKey == "" {
+		return none, false
+	}
+
+	value, exists := commit.Message.Metadata[p.BumpMetadataKey]
+	if !exists {
+		return none, false
+	}
+
+	bump, valid := ParseBump(value)
+	if !valid {
+		return none, false
+	}
+
+	return toVersionType(bump), true
+}
+
+// ParseBump normalize a bump footer value, e.g. " Major" to major. Return false if the value is not major,
+// minor, patch or none, such footers are ignored and the commit is bumped according to its type.
+func ParseBump(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	return value, isValidBump(value)
+}
+
+func (p SemVerCommitProcessor) typeVersionTypeToUpdate(commit CommitLog) (versionType, string) {
 	if commit.Message.IsBreakingChange {
-		return major
+		return major, "breaking change"
+	}
+
+	if commit.Message.Type == "" && p.NonConventional {
+		return p.NonConventionalVersionType, "non-conventional commit"
 	}
 
+	reason := "type " + commit.Message.Type
+
 	if _, exists := p.MajorVersionTypes[commit.Message.Type]; exists {
-		return major
+		return major, reason
 	}
 
 	if _, exists := p.MinorVersionTypes[commit.Message.Type]; exists {
-		return minor
+		return minor, reason
 	}
 
 	if _, exists := p.PatchVersionTypes[commit.Message.Type]; exists {
-		return patch
+		return patch, reason
 	}
 
 	if !contains(commit.Message.Type, p.KnownTypes) && p.IncludeUnknownTypeAsPatch {
-		return patch
+		if commit.Message.Type == "" {
+			return patch, "non-conventional commit"
+		}
+
+		return patch, "unknown type " + commit.Message.Type
+	}
+
+	return none, reason
+}
+
+func toVersionTypeMap(values map[string]string) map[string]versionType {
+	result := make(map[string]versionType)
+
+	for key, value := range values {
+		if v := toVersionType(value); v != none {
+			result[key] = v
+		}
 	}
 
-	return none
+	return result
+}
+
+func isValidBump(value string) bool {
+	return value == BumpNone || toVersionType(value) != none
+}
+
+func toVersionType(value string) versionType {
+	switch value {
+	case BumpMajor:
+		return major
+	case BumpMinor:
+		return minor
+	case BumpPatch:
+		return patch
+	default:
+		return none
+	}
 }
 
 func toMap(values []string) map[string]struct{} {
@@ -3,6 +3,7 @@ package sv
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
@@ -137,6 +138,7 @@ func TestToVersion(t *testing.T) {
 		{"empty version", "", TestVersion("0.0.0"), false},
 		{"invalid version", "abc", nil, true},
 		{"valid version", "1.2.3", TestVersion("1.2.3"), false},
+		{"pre-release version", "1.2.0-rc.1", TestVersion("1.2.0-rc.1"), false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,3 +185,562 @@ func TestIsValidVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version *semver.Version
+		suffix  string
+		want    string
+	}{
+		{"default suffix", TestVersion("1.2.0"), "-SNAPSHOT", "1.2.0-SNAPSHOT"},
+		{"custom suffix", TestVersion("1.2.0"), "-dev", "1.2.0-dev"},
+		{"empty suffix", TestVersion("1.2.0"), "", "1.2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.version.String()
+
+			if got := SnapshotVersion(*tt.version, tt.suffix); got != tt.want {
+				t.Errorf("SnapshotVersion() = %v, want %v", got, tt.want)
+			}
+
+			if tt.version.String() != original {
+				t.Errorf("SnapshotVersion() modified version = %v, want %v", tt.version, original)
+			}
+		})
+	}
+}
+
+func TestLatestCommitDate(t *testing.T) {
+	fallback := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		commits []CommitLog
+		want    time.Time
+	}{
+		{"empty commits", []CommitLog{}, fallback},
+		{"nil commits", nil, fallback},
+		{"newest commit", []CommitLog{{Timestamp: 1588366800}, {Timestamp: 1588280400}}, time.Unix(1588366800, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LatestCommitDate(tt.commits, fallback); !got.Equal(tt.want) {
+				t.Errorf("LatestCommitDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionBreakingChangeFooter(t *testing.T) {
+	mcfg := CommitMessageConfig{
+		Types: []string{"feat", "fix"},
+		BreakingChangeFooters: []CommitMessageBreakingChangeFooterConfig{
+			{Key: "Compatibility", Value: "broken"},
+		},
+	}
+
+	msg, err := NewMessageProcessor(mcfg, BranchesConfig{}).Parse("fix: change api", "Compatibility: broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := CommitLog{Message: msg, AuthorName: "a"}
+	p := NewSemVerCommitProcessor(VersioningConfig{UpdatePatch: []string{"fix"}}, mcfg)
+
+	if got, _ := p.NextVersion(TestVersion("1.0.0"), []CommitLog{commit}); !got.Equal(TestVersion("2.0.0")) {
+		t.Errorf("SemVerCommitProcessor.NextVersion() = %v, want %v", got, "2.0.0")
+	}
+
+	rn := NewReleaseNoteProcessor(ReleaseNotesConfig{
+		Sections: []ReleaseNotesSectionConfig{{Name: "Breaking Changes", SectionType: "breaking-changes"}},
+	}).Create(nil, "", time.Now(), []CommitLog{commit})
+
+	want := []ReleaseNoteSection{ReleaseNoteBreakingChangeSection{
+		Name: "Breaking Changes", Messages: []string{"change api"}, Items: []CommitLog{commit},
+	}}
+	if !reflect.DeepEqual(rn.Sections, want) {
+		t.Errorf("BaseReleaseNoteProcessor.Create() = %v, want %v", rn.Sections, want)
+	}
+}
+
+func TestBumpType(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous *semver.Version
+		current  *semver.Version
+		want     string
+	}{
+		{"major", TestVersion("1.2.3"), TestVersion("2.0.0"), BumpMajor},
+		{"minor", TestVersion("1.2.3"), TestVersion("1.3.0"), BumpMinor},
+		{"patch", TestVersion("1.2.3"), TestVersion("1.2.4"), BumpPatch},
+		{"first release", TestVersion("0.0.0"), TestVersion("0.1.0"), BumpMinor},
+		{"same version", TestVersion("1.2.3"), TestVersion("1.2.3"), ""},
+		{"older version", TestVersion("1.2.3"), TestVersion("1.2.2"), ""},
+		{"prerelease", TestVersion("1.2.3-rc.1"), TestVersion("1.2.3-rc.2"), ""},
+		{"nil previous", nil, TestVersion("1.2.3"), ""},
+		{"nil current", TestVersion("1.2.3"), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BumpType(tt.previous, tt.current); got != tt.want {
+				t.Errorf("BumpType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionMinCommits(t *testing.T) {
+	tests := []struct {
+		name        string
+		commits     []CommitLog
+		want        *semver.Version
+		wantUpdated bool
+	}{
+		{
+			"below threshold",
+			[]CommitLog{
+				TestCommitlog("patch", map[string]string{}, "a"),
+				TestCommitlog("minor", map[string]string{}, "a"),
+			},
+			TestVersion("1.0.0"),
+			false,
+		},
+		{
+			"below threshold with non releasable commits",
+			[]CommitLog{
+				TestCommitlog("patch", map[string]string{}, "a"),
+				TestCommitlog("none", map[string]string{}, "a"),
+				TestCommitlog("minor", map[string]string{}, "a"),
+			},
+			TestVersion("1.0.0"),
+			false,
+		},
+		{
+			"reach threshold",
+			[]CommitLog{
+				TestCommitlog("patch", map[string]string{}, "a"),
+				TestCommitlog("patch", map[string]string{}, "a"),
+				TestCommitlog("minor", map[string]string{}, "a"),
+			},
+			TestVersion("1.1.0"),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitProcessor(
+				VersioningConfig{
+					UpdateMinor: []string{"minor"},
+					UpdatePatch: []string{"patch"},
+					MinCommits:  3,
+				},
+				CommitMessageConfig{Types: []string{"minor", "patch", "none"}})
+			got, gotUpdated := p.NextVersion(TestVersion("1.0.0"), tt.commits)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() Version = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantUpdated != gotUpdated {
+				t.Errorf("SemVerCommitProcessor.NextVersion() Updated = %v, want %v", gotUpdated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_CountBumps(t *testing.T) {
+	p := NewSemVerCommitProcessor(
+		VersioningConfig{
+			UpdateMajor:   []string{"major"},
+			UpdateMinor:   []string{"minor"},
+			UpdatePatch:   []string{"patch"},
+			IgnoreUnknown: true,
+		},
+		CommitMessageConfig{Types: []string{"major", "minor", "patch", "none"}})
+
+	commits := []CommitLog{
+		TestCommitlog("patch", map[string]string{}, "a"),
+		TestCommitlog("patch", map[string]string{}, "a"),
+		TestCommitlog("minor", map[string]string{}, "a"),
+		TestCommitlog("patch", map[string]string{"breaking-change": "break"}, "a"),
+		TestCommitlog("none", map[string]string{}, "a"),
+		TestCommitlog("unknown", map[string]string{}, "a"),
+	}
+
+	want := BumpCounts{Major: 1, Minor: 1, Patch: 2}
+
+	got := p.CountBumps(commits)
+	if got != want {
+		t.Errorf("SemVerCommitProcessor.CountBumps() = %v, want %v", got, want)
+	}
+
+	if got.Releasable() != 4 {
+		t.Errorf("BumpCounts.Releasable() = %v, want %v", got.Releasable(), 4)
+	}
+}
+
+func TestSemVerCommitProcessor_Bump(t *testing.T) {
+	p := NewSemVerCommitProcessor(
+		VersioningConfig{
+			UpdateMajor:   []string{"major"},
+			UpdateMinor:   []string{"minor"},
+			UpdatePatch:   []string{"patch"},
+			IgnoreUnknown: true,
+		},
+		CommitMessageConfig{Types: []string{"major", "minor", "patch", "none"}})
+
+	tests := []struct {
+		name       string
+		commit     CommitLog
+		want       string
+		wantReason string
+	}{
+		{"major type", TestCommitlog("major", map[string]string{}, "a"), BumpMajor, "type major"},
+		{"minor type", TestCommitlog("minor", map[string]string{}, "a"), BumpMinor, "type minor"},
+		{"patch type", TestCommitlog("patch", map[string]string{}, "a"), BumpPatch, "type patch"},
+		{
+			"breaking change", TestCommitlog("patch", map[string]string{"breaking-change": "break"}, "a"),
+			BumpMajor, "breaking change",
+		},
+		{"not releasable", TestCommitlog("none", map[string]string{}, "a"), "", "type none"},
+		{"ignored unknown", TestCommitlog("unknown", map[string]string{}, "a"), "", "type unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := p.Bump(tt.commit)
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("SemVerCommitProcessor.Bump() = %v, %v, want %v, %v", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_BumpRules(t *testing.T) {
+	p := NewSemVerCommitProcessor(
+		VersioningConfig{
+			UpdateMinor:  []string{"feat"},
+			UpdatePatch:  []string{"fix"},
+			ScopeMinBump: map[string]string{"api": BumpMinor},
+			MaxBump:      BumpMinor,
+			BumpFooter:   "version-bump",
+		},
+		CommitMessageConfig{Types: []string{"feat", "fix", "docs"}})
+
+	scoped := TestCommitlog("docs", map[string]string{}, "a")
+	scoped.Message.Scope = "api"
+
+	tests := []struct {
+		name       string
+		commit     CommitLog
+		want       string
+		wantReason string
+	}{
+		{"type", TestCommitlog("fix", map[string]string{}, "a"), BumpPatch, "type fix"},
+		{"unknown type", TestCommitlog("perf", map[string]string{}, "a"), BumpPatch, "unknown type perf"},
+		{
+			"footer override", TestCommitlog("feat", map[string]string{"version-bump": "patch"}, "a"),
+			BumpPatch, "version-bump footer",
+		},
+		{"scope minimum", scoped, BumpMinor, "scope api minimum"},
+		{
+			"max bump", TestCommitlog("fix", map[string]string{"breaking-change": "break"}, "a"),
+			BumpMinor, "max-bump caps breaking change",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := p.Bump(tt.commit)
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("SemVerCommitProcessor.Bump() = %v, %v, want %v, %v", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionScopeMinBump(t *testing.T) {
+	p := NewSemVerCommitProcessor(
+		VersioningConfig{
+			UpdateMinor:  []string{"feat"},
+			UpdatePatch:  []string{"fix"},
+			ScopeMinBump: map[string]string{"public-api": BumpMinor, "invalid": "unknown"},
+		},
+		CommitMessageConfig{Types: []string{"feat", "fix"}})
+
+	withScope := func(ctype, scope string, metadata map[string]string) CommitLog {
+		commit := TestCommitlog(ctype, metadata, "a")
+		commit.Message.Scope = scope
+
+		return commit
+	}
+
+	tests := []struct {
+		name   string
+		commit CommitLog
+		want   *semver.Version
+	}{
+		{"fix on public-api scope", withScope("fix", "public-api", map[string]string{}), TestVersion("1.1.0")},
+		{"fix on internal scope", withScope("fix", "internal", map[string]string{}), TestVersion("1.0.1")},
+		{"fix on invalid mapping", withScope("fix", "invalid", map[string]string{}), TestVersion("1.0.1")},
+		{
+			"breaking change on public-api scope",
+			withScope("fix", "public-api", map[string]string{BreakingChangeMetadataKey: "breaks"}),
+			TestVersion("2.0.0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, updated := p.NextVersion(TestVersion("1.0.0"), []CommitLog{tt.commit})
+			if !updated || !got.Equal(tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() = %v, updated %v, want %v", got, updated, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionBumpFooter(t *testing.T) {
+	mcfg := CommitMessageConfig{
+		Types:  []string{"feat", "fix", "chore"},
+		Footer: map[string]CommitMessageFooterConfig{"version-bump": {Key: "Version-Bump"}},
+	}
+	p := NewSemVerCommitProcessor(
+		VersioningConfig{UpdateMinor: []string{"feat"}, UpdatePatch: []string{"fix"}, BumpFooter: "version-bump"}, mcfg,
+	)
+	mp := NewMessageProcessor(mcfg, newBranchCfg(false))
+
+	commit := func(subject, body string) CommitLog {
+		message, err := mp.Parse(subject, body)
+		if err != nil {
+			t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+		}
+
+		return CommitLog{Message: message}
+	}
+
+	tests := []struct {
+		name        string
+		commits     []CommitLog
+		want        *semver.Version
+		wantUpdated bool
+	}{
+		{"fix forced to major", []CommitLog{commit("fix: something", "Version-Bump: major")}, TestVersion("2.0.0"), true},
+		{"chore forced to minor", []CommitLog{commit("chore: something", "Version-Bump: Minor")}, TestVersion("1.1.0"), true},
+		{"feat forced to none", []CommitLog{commit("feat: something", "Version-Bump: none")}, TestVersion("1.0.0"), false},
+		{
+			"breaking change forced to patch",
+			[]CommitLog{commit("feat!: something", "Version-Bump: patch")},
+			TestVersion("1.0.1"), true,
+		},
+		{"invalid value ignored", []CommitLog{commit("feat: something", "Version-Bump: huge")}, TestVersion("1.1.0"), true},
+		{
+			"forced patch mixed with feat",
+			[]CommitLog{commit("feat: something", ""), commit("chore: something", "Version-Bump: patch")},
+			TestVersion("1.1.0"), true,
+		},
+		{
+			"forced major mixed with feat and fix",
+			[]CommitLog{
+				commit("feat: something", ""),
+				commit("chore: something", "Version-Bump: major"),
+				commit("fix: something", ""),
+			},
+			TestVersion("2.0.0"), true,
+		},
+		{
+			"forced none mixed with fix",
+			[]CommitLog{commit("feat: something", "Version-Bump: none"), commit("fix: something", "")},
+			TestVersion("1.0.1"), true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, updated := p.NextVersion(TestVersion("1.0.0"), tt.commits)
+			if updated != tt.wantUpdated || !got.Equal(tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() = %v, updated %v, want %v, updated %v",
+					got, updated, tt.want, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionMaxBump(t *testing.T) {
+	breaking := TestCommitlog("fix", map[string]string{BreakingChangeMetadataKey: "breaks"}, "a")
+	majorType := TestCommitlog("major", map[string]string{}, "a")
+	feat := TestCommitlog("feat", map[string]string{}, "a")
+	fix := TestCommitlog("fix", map[string]string{}, "a")
+
+	tests := []struct {
+		name    string
+		maxBump string
+		commit  CommitLog
+		want    *semver.Version
+	}{
+		{"without cap", "", breaking, TestVersion("1.0.0")},
+		{"breaking change capped to minor", BumpMinor, breaking, TestVersion("0.2.0")},
+		{"major type capped to minor", BumpMinor, majorType, TestVersion("0.2.0")},
+		{"feature capped to patch", BumpPatch, feat, TestVersion("0.1.1")},
+		{"below cap unchanged", BumpMinor, fix, TestVersion("0.1.1")},
+		{"invalid cap ignored", "unknown", breaking, TestVersion("1.0.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitProcessor(
+				VersioningConfig{
+					UpdateMajor: []string{"major"},
+					UpdateMinor: []string{"feat"},
+					UpdatePatch: []string{"fix"},
+					MaxBump:     tt.maxBump,
+				},
+				CommitMessageConfig{Types: []string{"major", "feat", "fix"}})
+
+			got, updated := p.NextVersion(TestVersion("0.1.0"), []CommitLog{tt.commit})
+			if !updated || !got.Equal(tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() = %v, updated %v, want %v", got, updated, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionNonConventional(t *testing.T) {
+	nonConventional := TestCommitlog("", map[string]string{}, "a")
+	unmapped := TestCommitlog("chore", map[string]string{}, "a")
+
+	tests := []struct {
+		name                string
+		nonConventionalBump string
+		ignoreUnknown       bool
+		commit              CommitLog
+		want                *semver.Version
+		wantUpdated         bool
+	}{
+		{"non conventional as unknown type", "", false, nonConventional, TestVersion("0.1.1"), true},
+		{"non conventional ignored as unknown type", "", true, nonConventional, TestVersion("0.1.0"), false},
+		{"non conventional none", BumpNone, false, nonConventional, TestVersion("0.1.0"), false},
+		{"non conventional minor", BumpMinor, true, nonConventional, TestVersion("0.2.0"), true},
+		{"conventional unmapped with none", BumpNone, false, unmapped, TestVersion("0.1.0"), false},
+		{"conventional unmapped with minor", BumpMinor, false, unmapped, TestVersion("0.1.0"), false},
+		{"invalid bump handled as unknown type", "unknown", false, nonConventional, TestVersion("0.1.1"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitProcessor(
+				VersioningConfig{
+					UpdateMinor:         []string{"feat"},
+					UpdatePatch:         []string{"fix"},
+					IgnoreUnknown:       tt.ignoreUnknown,
+					NonConventionalBump: tt.nonConventionalBump,
+				},
+				CommitMessageConfig{Types: []string{"feat", "fix", "chore"}})
+
+			got, updated := p.NextVersion(TestVersion("0.1.0"), []CommitLog{tt.commit})
+			if updated != tt.wantUpdated || !got.Equal(tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() = %v, updated %v, want %v, updated %v",
+					got, updated, tt.want, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestSemVerCommitProcessor_NextVersionPreRelease(t *testing.T) {
+	feat := TestCommitlog("feat", map[string]string{}, "a")
+	fix := TestCommitlog("fix", map[string]string{}, "a")
+	breaking := TestCommitlog("fix", map[string]string{BreakingChangeMetadataKey: "breaks"}, "a")
+
+	tests := []struct {
+		name        string
+		preRelease  string
+		version     *semver.Version
+		commit      CommitLog
+		want        *semver.Version
+		wantUpdated bool
+	}{
+		{"release without pre-release", "rc", TestVersion("1.1.0"), feat, TestVersion("1.2.0"), true},
+		{"fix of minor pre-release", "rc", TestVersion("1.2.0-rc.2"), fix, TestVersion("1.2.0"), true},
+		{"feat of minor pre-release", "rc", TestVersion("1.2.0-rc.2"), feat, TestVersion("1.2.0"), true},
+		{"feat of patch pre-release", "rc", TestVersion("1.2.1-rc.1"), feat, TestVersion("1.3.0"), true},
+		{"breaking of minor pre-release", "rc", TestVersion("1.2.0-rc.1"), breaking, TestVersion("2.0.0"), true},
+		{"breaking of major pre-release", "rc", TestVersion("2.0.0-rc.1"), breaking, TestVersion("2.0.0"), true},
+		{"not configured", "", TestVersion("1.2.0-rc.2"), feat, TestVersion("1.3.0"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSemVerCommitProcessor(
+				VersioningConfig{
+					UpdateMajor: []string{},
+					UpdateMinor: []string{"feat"},
+					UpdatePatch: []string{"fix"},
+					PreRelease:  PreReleaseConfig{Name: tt.preRelease},
+				},
+				CommitMessageConfig{Types: []string{"feat", "fix"}})
+
+			got, updated := p.NextVersion(tt.version, []CommitLog{tt.commit})
+			if updated != tt.wantUpdated || !got.Equal(tt.want) {
+				t.Errorf("SemVerCommitProcessor.NextVersion() = %v, updated %v, want %v, updated %v",
+					got, updated, tt.want, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestPreReleaseVersion(t *testing.T) {
+	rc := PreReleaseConfig{Name: "rc"}
+
+	tests := []struct {
+		name    string
+		cfg     PreReleaseConfig
+		current *semver.Version
+		next    *semver.Version
+		want    string
+		wantErr bool
+	}{
+		{"first pre-release", rc, TestVersion("1.1.0"), TestVersion("1.2.0"), "1.2.0-rc.1", false},
+		{"next pre-release", rc, TestVersion("1.2.0-rc.1"), TestVersion("1.2.0"), "1.2.0-rc.2", false},
+		{"pre-release of other version", rc, TestVersion("1.2.0-rc.3"), TestVersion("2.0.0"), "2.0.0-rc.1", false},
+		{"other pre-release name", rc, TestVersion("1.2.0-beta.3"), TestVersion("1.2.0"), "1.2.0-rc.1", false},
+		{"no current version", rc, nil, TestVersion("0.1.0"), "0.1.0-rc.1", false},
+		{
+			"custom pattern", PreReleaseConfig{Name: "beta", Pattern: "build%d"},
+			TestVersion("1.2.0-beta.build4"), TestVersion("1.2.0"), "1.2.0-beta.build5", false,
+		},
+		{"not configured", PreReleaseConfig{}, TestVersion("1.1.0"), TestVersion("1.2.0"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PreReleaseVersion(tt.current, *tt.next, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PreReleaseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("PreReleaseVersion() = %v, want %v", got.String(), tt.want)
+			}
+
+			if parsed, err := ToVersion(got.String()); err != nil || !parsed.Equal(&got) {
+				t.Errorf("ToVersion(%s) = %v, %v, want %v", got.String(), parsed, err, got.String())
+			}
+		})
+	}
+}
+
+func TestCommitStats_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats CommitStats
+		want  string
+	}{
+		{"singular", CommitStats{1, 1, 1}, "1 file changed, 1 insertion(+), 1 deletion(-)"},
+		{"plural", CommitStats{2, 3, 0}, "2 files changed, 3 insertions(+), 0 deletions(-)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.String(); got != tt.want {
+				t.Errorf("CommitStats.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
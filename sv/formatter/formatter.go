@@ -2,26 +2,48 @@ package formatter
 
 import (
 	"bytes"
+	"regexp"
 	"sort"
+	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/thegeeklab/git-sv/sv"
 )
 
 type releaseNoteTemplateVariables struct {
-	Release     string
-	Tag         string
-	Version     *semver.Version
-	Date        time.Time
-	Sections    []sv.ReleaseNoteSection
-	AuthorNames []string
+	Release      string
+	Tag          string
+	Version      *semver.Version
+	Date         time.Time
+	Sections     []sv.ReleaseNoteSection
+	AuthorNames  []string
+	Authors      []author
+	EmptyMessage string
+	Bump         string
+	Compare      string
+	Stats        *sv.CommitStats
 }
 
+type author struct {
+	Name  string
+	Email string
+}
+
+// Supported release note formats.
+const (
+	FormatMarkdown = "md"
+	FormatRST      = "rst"
+	FormatTable    = "table"
+	FormatGitHub   = "github"
+)
+
 // OutputFormatter output formatter interface.
 type OutputFormatter interface {
 	FormatReleaseNote(releasenote sv.ReleaseNote) ([]byte, error)
+	FormatReleaseNoteAs(format string, releasenote sv.ReleaseNote) ([]byte, error)
 	FormatChangelog(releasenotes []sv.ReleaseNote) ([]byte, error)
 }
 
@@ -37,12 +59,20 @@ func NewOutputFormatter(tpls *template.Template) *BaseOutputFormatter {
 
 // FormatReleaseNote format a release note.
 func (p BaseOutputFormatter) FormatReleaseNote(releasenote sv.ReleaseNote) ([]byte, error) {
+	return p.FormatReleaseNoteAs(FormatMarkdown, releasenote)
+}
+
+// FormatReleaseNoteAs format a release note using the releasenotes template of the given format.
+func (p BaseOutputFormatter) FormatReleaseNoteAs(format string, releasenote sv.ReleaseNote) ([]byte, error) {
 	var b bytes.Buffer
-	if err := p.templates.ExecuteTemplate(&b, "releasenotes-md.tpl", releaseNoteVariables(releasenote)); err != nil {
+	if err := p.templates.ExecuteTemplate(
+		&b, "releasenotes-"+format+".tpl", releaseNoteVariables(releasenote),
+	); err != nil {
 		return b.Bytes(), err
 	}
 
-	return b.Bytes(), nil
+	// templates without a release header start with the blank lines of the first section
+	return bytes.TrimLeft(b.Bytes(), "\n"), nil
 }
 
 // FormatChangelog format a changelog.
@@ -67,15 +97,31 @@ func releaseNoteVariables(releasenote sv.ReleaseNote) releaseNoteTemplateVariabl
 	}
 
 	return releaseNoteTemplateVariables{
-		Release:     release,
-		Tag:         releasenote.Tag,
-		Version:     releasenote.Version,
-		Date:        releasenote.Date,
-		Sections:    releasenote.Sections,
-		AuthorNames: toSortedArray(releasenote.AuthorsNames),
+		Release:      release,
+		Tag:          releasenote.Tag,
+		Version:      releasenote.Version,
+		Date:         releasenote.Date,
+		Sections:     releasenote.Sections,
+		AuthorNames:  toSortedArray(releasenote.AuthorsNames),
+		Authors:      toAuthors(releasenote.AuthorsNames, releasenote.AuthorsEmails),
+		EmptyMessage: releasenote.EmptyMessage,
+		Bump:         releasenote.Bump,
+		Compare:      releasenote.Compare,
+		Stats:        releasenote.Stats,
 	}
 }
 
+func toAuthors(names map[string]struct{}, emails map[string]string) []author {
+	sorted := toSortedArray(names)
+	result := make([]author, len(sorted))
+
+	for i, name := range sorted {
+		result[i] = author{Name: name, Email: emails[name]}
+	}
+
+	return result
+}
+
 func toSortedArray(input map[string]struct{}) []string {
 	result := make([]string, len(input))
 	i := 0
@@ -89,3 +135,71 @@ func toSortedArray(input map[string]struct{}) []string {
 
 	return result
 }
+
+//nolint:gochecknoglobals
+var (
+	// listMarkerRegex match the marker of a list item, e.g. "- " or "1. ".
+	listMarkerRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+`)
+)
+
+// Wrap hard-wraps each line of the output at width columns, continuation lines of list items
+// are indented to the item text. Headings, table rows, html tags, e.g. <details>, and lines within
+// width are kept untouched.
+func Wrap(output []byte, width int) []byte {
+	if width <= 0 {
+		return output
+	}
+
+	lines := strings.Split(string(output), "\n")
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) <= width || !wrappable(line) {
+			result = append(result, line)
+
+			continue
+		}
+
+		result = append(result, wrapLine(line, width)...)
+	}
+
+	return []byte(strings.Join(result, "\n"))
+}
+
+// wrappable return false for lines breaking the markup if wrapped, e.g. headings, table rows and html tags.
+func wrappable(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return !strings.HasPrefix(line, "#") && !strings.HasPrefix(trimmed, "|") && !strings.HasPrefix(trimmed, "<")
+}
+
+func wrapLine(line string, width int) []string {
+	prefix := listMarkerRegex.FindString(line)
+	if prefix == "" {
+		prefix = line[:len(line)-len(strings.TrimLeft(line, " "))]
+	}
+
+	indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+
+	var (
+		result  []string
+		current = prefix
+		empty   = true
+	)
+
+	for _, word := range strings.Fields(line[len(prefix):]) {
+		if !empty && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			result = append(result, current)
+			current, empty = indent, true
+		}
+
+		if !empty {
+			current += " "
+		}
+
+		current += word
+		empty = false
+	}
+
+	return append(result, current)
+}
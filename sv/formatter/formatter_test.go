@@ -2,6 +2,8 @@ package formatter
 
 import (
 	"bytes"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,6 +40,39 @@ var fullChangeLog = `## v1.0.0 (2020-05-01)
 
 - break change message`
 
+var breakingChangeWithHashChangelog = `## v1.0.0 (2020-05-01)
+
+### Breaking Changes
+
+- break change message (abc123)`
+
+var breakingChangeWithoutHashChangelog = `## v1.0.0 (2020-05-01)
+
+### Breaking Changes
+
+- break change message`
+
+var collapsedIssuesChangelog = `## v1.0.0 (2020-05-01)
+
+### Bug Fixes
+
+- subject text () (JIRA-1)
+- subject text ()`
+
+var emojiChangelog = `## v1.0.0 (2020-05-01)
+
+### ✨ Features
+
+- subject text ()
+
+### 💥 Breaking Changes
+
+- break change message`
+
+var emptyMessageChangelog = `## v1.0.0 (2020-05-01)
+
+No breaking changes.`
+
 func TestBaseOutputFormatter_FormatReleaseNote(t *testing.T) {
 	date, _ := time.Parse("2006-01-02", "2020-05-01")
 
@@ -52,6 +87,37 @@ func TestBaseOutputFormatter_FormatReleaseNote(t *testing.T) {
 		{"without version", emptyReleaseNote("", date.Truncate(time.Minute)), emptyVersionChangelog, false},
 		{"non versioning tag", emptyReleaseNote("abc", date.Truncate(time.Minute)), nonVersioningChangelog, false},
 		{"full changelog", fullReleaseNote("1.0.0", date.Truncate(time.Minute)), fullChangeLog, false},
+		{
+			"empty with message",
+			emptyReleaseNoteWithMessage("1.0.0", date.Truncate(time.Minute), "No breaking changes."),
+			emptyMessageChangelog,
+			false,
+		},
+		{
+			"breaking change only",
+			fullReleaseNote("1.0.0", date.Truncate(time.Minute)).BreakingChangesOnly(),
+			breakingChangeWithoutHashChangelog,
+			false,
+		},
+		{
+			"breaking change with hash",
+			breakingChangeReleaseNote("1.0.0", date.Truncate(time.Minute), true),
+			breakingChangeWithHashChangelog,
+			false,
+		},
+		{
+			"breaking change without hash",
+			breakingChangeReleaseNote("1.0.0", date.Truncate(time.Minute), false),
+			breakingChangeWithoutHashChangelog,
+			false,
+		},
+		{"section emoji", emojiReleaseNote("1.0.0", date.Truncate(time.Minute)), emojiChangelog, false},
+		{
+			"collapsed issues",
+			collapsedIssuesReleaseNote("1.0.0", date.Truncate(time.Minute)),
+			collapsedIssuesChangelog,
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -77,6 +143,13 @@ func emptyReleaseNote(tag string, date time.Time) sv.ReleaseNote {
 	}
 }
 
+func emptyReleaseNoteWithMessage(tag string, date time.Time, message string) sv.ReleaseNote {
+	rn := emptyReleaseNote(tag, date)
+	rn.EmptyMessage = message
+
+	return rn
+}
+
 func fullReleaseNote(tag string, date time.Time) sv.ReleaseNote {
 	v, _ := semver.NewVersion(tag)
 	sections := []sv.ReleaseNoteSection{
@@ -101,6 +174,49 @@ func fullReleaseNote(tag string, date time.Time) sv.ReleaseNote {
 	return sv.TestReleaseNote(v, tag, date, sections, map[string]struct{}{"a": {}})
 }
 
+func breakingChangeReleaseNote(tag string, date time.Time, showHash bool) sv.ReleaseNote {
+	v, _ := semver.NewVersion(tag)
+	commit := sv.TestCommitlog("feat", map[string]string{"breaking-change": "break change message"}, "a")
+	commit.Hash = "abc123"
+	sections := []sv.ReleaseNoteSection{
+		sv.ReleaseNoteBreakingChangeSection{
+			Name:     "Breaking Changes",
+			Messages: []string{"break change message"},
+			Items:    []sv.CommitLog{commit},
+			ShowHash: showHash,
+		},
+	}
+
+	return sv.TestReleaseNote(v, tag, date, sections, map[string]struct{}{"a": {}})
+}
+
+func emojiReleaseNote(tag string, date time.Time) sv.ReleaseNote {
+	v, _ := semver.NewVersion(tag)
+	features := sv.TestNewReleaseNoteCommitsSection(
+		"Features", []string{"feat"}, []sv.CommitLog{sv.TestCommitlog("feat", map[string]string{}, "a")},
+	)
+	features.Emoji = "✨"
+	sections := []sv.ReleaseNoteSection{
+		features,
+		sv.ReleaseNoteBreakingChangeSection{
+			Name: "Breaking Changes", Messages: []string{"break change message"}, Emoji: "💥",
+		},
+	}
+
+	return sv.TestReleaseNote(v, tag, date, sections, map[string]struct{}{"a": {}})
+}
+
+func collapsedIssuesReleaseNote(tag string, date time.Time) sv.ReleaseNote {
+	v, _ := semver.NewVersion(tag)
+	section := sv.TestNewReleaseNoteCommitsSection("Bug Fixes", []string{"fix"}, []sv.CommitLog{
+		sv.TestCommitlog("fix", map[string]string{sv.IssueMetadataKey: "JIRA-1"}, "a"),
+		sv.TestCommitlog("fix", map[string]string{sv.IssueMetadataKey: "JIRA-1"}, "a"),
+	})
+	section.CollapseIssues = true
+
+	return sv.TestReleaseNote(v, tag, date, []sv.ReleaseNoteSection{section}, map[string]struct{}{"a": {}})
+}
+
 func Test_checkTemplatesExecution(t *testing.T) {
 	tpls := NewOutputFormatter(tmpls).templates
 	tests := []struct {
@@ -109,6 +225,8 @@ func Test_checkTemplatesExecution(t *testing.T) {
 	}{
 		{"changelog-md.tpl", changelogVariables("v1.0.0", "v1.0.1")},
 		{"releasenotes-md.tpl", releaseNotesVariables("v1.0.0")},
+		{"releasenotes-table.tpl", releaseNotesVariables("v1.0.0")},
+		{"releasenotes-github.tpl", releaseNotesVariables("v1.0.0")},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +278,342 @@ func changelogVariables(releases ...string) []releaseNoteTemplateVariables {
 
 	return variables
 }
+
+func Test_toAuthors(t *testing.T) {
+	names := map[string]struct{}{"b": {}, "a": {}}
+	emails := map[string]string{"a": "a@example.com"}
+	want := []author{{Name: "a", Email: "a@example.com"}, {Name: "b"}}
+
+	if got := toAuthors(names, emails); !reflect.DeepEqual(got, want) {
+		t.Errorf("toAuthors() = %v, want %v", got, want)
+	}
+}
+
+func Test_releaseNoteVariablesBump(t *testing.T) {
+	rn := emptyReleaseNote("1.1.0", time.Time{})
+	rn.Bump = sv.BumpMinor
+
+	if got := releaseNoteVariables(rn); got.Bump != sv.BumpMinor {
+		t.Errorf("releaseNoteVariables() Bump = %v, want %v", got.Bump, sv.BumpMinor)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{"disabled", "- a long line with some words", 0, "- a long line with some words"},
+		{"short lines untouched", "### Features\n\n- short item", 20, "### Features\n\n- short item"},
+		{"long list item", "- a long line with some words", 12, "- a long\n  line with\n  some words"},
+		{"numbered list item", "1. a long line with words", 12, "1. a long\n   line with\n   words"},
+		{"plain text", "a long line with some words", 12, "a long line\nwith some\nwords"},
+		{"long word", "- averyveryverylongword end", 10, "- averyveryverylongword\n  end"},
+		{"heading untouched", "## v1.0.0 (2020-05-01) with a long title", 10, "## v1.0.0 (2020-05-01) with a long title"},
+		{
+			"table row untouched", "| feat | api | a long description | author | abc123 |", 20,
+			"| feat | api | a long description | author | abc123 |",
+		},
+		{
+			"html untouched", "<details>\n<summary>12 changes in this long section</summary>", 20,
+			"<details>\n<summary>12 changes in this long section</summary>",
+		},
+		{
+			"mixed lines",
+			"### Bug Fixes\n\n- fix something with a long description (abc123)\n- short (def456)",
+			30,
+			"### Bug Fixes\n\n- fix something with a long\n  description (abc123)\n- short (def456)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Wrap([]byte(tt.input), tt.width)); got != tt.want {
+				t.Errorf("Wrap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteAsRST(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	tests := []struct {
+		name  string
+		input sv.ReleaseNote
+		want  string
+	}{
+		{"with date", emptyReleaseNote("1.0.0", date), "v1.0.0 (2020-05-01)\n==================="},
+		{"without date", emptyReleaseNote("1.0.0", time.Time{}), "v1.0.0\n======"},
+		{
+			"full release note",
+			fullReleaseNote("1.0.0", date),
+			`v1.0.0 (2020-05-01)
+===================
+
+Features
+~~~~~~~~
+
+- subject text ()
+
+Bug Fixes
+~~~~~~~~~
+
+- subject text ()
+
+Build
+~~~~~
+
+- subject text ()
+
+Breaking Changes
+~~~~~~~~~~~~~~~~
+
+- break change message`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOutputFormatter(tmpls).FormatReleaseNoteAs(FormatRST, tt.input)
+			if err != nil {
+				t.Fatalf("BaseOutputFormatter.FormatReleaseNoteAs() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("BaseOutputFormatter.FormatReleaseNoteAs() = %q, want %q", got, tt.want)
+			}
+
+			lines := strings.Split(string(got), "\n")
+			for i := 1; i < len(lines); i++ {
+				if lines[i] != "" && strings.Trim(lines[i], "=~") == "" && len(lines[i]) != len(lines[i-1]) {
+					t.Errorf("underline %q does not match header %q", lines[i], lines[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteScopePrefix(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	scoped := sv.TestCommitlog("feat", map[string]string{}, "a")
+	scoped.Hash = "abc123"
+	scoped.Message.Scope = "api"
+	scoped.Message.Description = "add endpoint"
+
+	unscoped := sv.TestCommitlog("feat", map[string]string{}, "a")
+	unscoped.Hash = "def456"
+	unscoped.Message.Description = "add option"
+
+	releaseNote := sv.TestReleaseNote(semver.MustParse("1.0.0"), "1.0.0", date, []sv.ReleaseNoteSection{
+		sv.TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []sv.CommitLog{scoped, unscoped}),
+	}, map[string]struct{}{"a": {}})
+
+	for _, format := range []string{FormatMarkdown, FormatRST} {
+		t.Run(format, func(t *testing.T) {
+			got, err := NewOutputFormatter(tmpls).FormatReleaseNoteAs(format, releaseNote)
+			if err != nil {
+				t.Fatalf("BaseOutputFormatter.FormatReleaseNoteAs() error = %v", err)
+			}
+
+			for _, want := range []string{"- **api:** add endpoint (abc123)", "- add option (def456)"} {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("BaseOutputFormatter.FormatReleaseNoteAs() = %q, want to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteSectionOrder(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	p := sv.NewReleaseNoteProcessor(sv.ReleaseNotesConfig{
+		Sections: []sv.ReleaseNotesSectionConfig{
+			{Name: "Features", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+			{Name: "Bug Fixes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+		},
+		SectionOrder: []string{"Bug Fixes", "Features"},
+	})
+	releaseNote := p.Create(semver.MustParse("1.0.0"), "1.0.0", date, []sv.CommitLog{
+		sv.TestCommitlog("feat", map[string]string{}, "a"),
+		sv.TestCommitlog("fix", map[string]string{}, "a"),
+	})
+
+	got, err := NewOutputFormatter(tmpls).FormatReleaseNote(releaseNote)
+	if err != nil {
+		t.Fatalf("BaseOutputFormatter.FormatReleaseNote() error = %v", err)
+	}
+
+	fixes := strings.Index(string(got), "### Bug Fixes")
+	features := strings.Index(string(got), "### Features")
+
+	if fixes < 0 || features < 0 || fixes > features {
+		t.Errorf("BaseOutputFormatter.FormatReleaseNote() = %q, want Bug Fixes before Features", got)
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteCollapsed(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	p := sv.NewReleaseNoteProcessor(sv.ReleaseNotesConfig{
+		Sections: []sv.ReleaseNotesSectionConfig{
+			{Name: "Features", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+			{Name: "Bug Fixes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+		},
+		CollapseThreshold: 2,
+	})
+	releaseNote := p.Create(semver.MustParse("1.0.0"), "1.0.0", date, []sv.CommitLog{
+		sv.TestCommitlog("feat", map[string]string{}, "a"),
+		sv.TestCommitlog("feat", map[string]string{}, "a"),
+		sv.TestCommitlog("feat", map[string]string{}, "a"),
+		sv.TestCommitlog("fix", map[string]string{}, "a"),
+		sv.TestCommitlog("fix", map[string]string{}, "a"),
+	})
+
+	got, err := NewOutputFormatter(tmpls).FormatReleaseNote(releaseNote)
+	if err != nil {
+		t.Fatalf("BaseOutputFormatter.FormatReleaseNote() error = %v", err)
+	}
+
+	features := strings.Index(string(got), "### Features")
+	fixes := strings.Index(string(got), "### Bug Fixes")
+
+	if features < 0 || fixes < 0 {
+		t.Fatalf("BaseOutputFormatter.FormatReleaseNote() = %q, want Features and Bug Fixes sections", got)
+	}
+
+	if !strings.Contains(string(got[features:fixes]), "<details>\n<summary>3 changes</summary>") ||
+		!strings.Contains(string(got[features:fixes]), "</details>") {
+		t.Errorf("BaseOutputFormatter.FormatReleaseNote() = %q, want collapsed Features section", got)
+	}
+
+	if strings.Contains(string(got[fixes:]), "<details>") {
+		t.Errorf("BaseOutputFormatter.FormatReleaseNote() = %q, want expanded Bug Fixes section", got)
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNotePR(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	withPR := sv.TestCommitlog("feat", map[string]string{sv.PRMetadataKey: "42"}, "a")
+	withPR.Hash = "abc123"
+	withPR.Message.Description = "add endpoint"
+
+	withoutPR := sv.TestCommitlog("feat", map[string]string{}, "a")
+	withoutPR.Hash = "def456"
+	withoutPR.Message.Description = "add option"
+
+	releaseNote := sv.TestReleaseNote(semver.MustParse("1.0.0"), "1.0.0", date, []sv.ReleaseNoteSection{
+		sv.TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []sv.CommitLog{withPR, withoutPR}),
+	}, map[string]struct{}{"a": {}})
+
+	for _, format := range []string{FormatMarkdown, FormatRST} {
+		t.Run(format, func(t *testing.T) {
+			got, err := NewOutputFormatter(tmpls).FormatReleaseNoteAs(format, releaseNote)
+			if err != nil {
+				t.Fatalf("BaseOutputFormatter.FormatReleaseNoteAs() error = %v", err)
+			}
+
+			for _, want := range []string{"- add endpoint (abc123) (#42)\n", "- add option (def456)"} {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("BaseOutputFormatter.FormatReleaseNoteAs() = %q, want to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteAsTable(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	commit := sv.TestCommitlog("feat", map[string]string{}, "John Doe")
+	commit.Hash = "abc123"
+	commit.Message.Scope = "api"
+	commit.Message.Description = "support a|b"
+
+	releaseNote := sv.TestReleaseNote(semver.MustParse("1.0.0"), "1.0.0", date, []sv.ReleaseNoteSection{
+		sv.TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []sv.CommitLog{commit}),
+		sv.ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"break change message"}},
+	}, map[string]struct{}{"John Doe": {}})
+
+	tests := []struct {
+		name  string
+		input sv.ReleaseNote
+		want  string
+	}{
+		{"empty", emptyReleaseNoteWithMessage("1.0.0", date, "No changes."), "## v1.0.0 (2020-05-01)\n\nNo changes."},
+		{
+			"with commit",
+			releaseNote,
+			`## v1.0.0 (2020-05-01)
+
+| Type | Scope | Description | Author | Hash |
+| ---- | ----- | ----------- | ------ | ---- |
+| feat | api | support a\|b | John Doe | abc123 |`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOutputFormatter(tmpls).FormatReleaseNoteAs(FormatTable, tt.input)
+			if err != nil {
+				t.Fatalf("BaseOutputFormatter.FormatReleaseNoteAs() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("BaseOutputFormatter.FormatReleaseNoteAs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseOutputFormatter_FormatReleaseNoteAsGitHub(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+
+	withCompare := fullReleaseNote("1.0.0", date)
+	withCompare.Compare = "0.9.0...1.0.0"
+
+	tests := []struct {
+		name  string
+		input sv.ReleaseNote
+		want  string
+	}{
+		{
+			"full release note",
+			withCompare,
+			`### Features
+
+- subject text ()
+
+### Bug Fixes
+
+- subject text ()
+
+### Build
+
+- subject text ()
+
+### Breaking Changes
+
+- break change message
+
+**Contributors**: a
+
+**Full Changelog**: 0.9.0...1.0.0`,
+		},
+		{"empty", emptyReleaseNoteWithMessage("1.0.0", date, "No changes."), "No changes."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOutputFormatter(tmpls).FormatReleaseNoteAs(FormatGitHub, tt.input)
+			if err != nil {
+				t.Fatalf("BaseOutputFormatter.FormatReleaseNoteAs() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("BaseOutputFormatter.FormatReleaseNoteAs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
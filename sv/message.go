@@ -5,14 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
 )
 
 const (
 	BreakingChangeFooterKey   = "BREAKING CHANGE"
 	BreakingChangeMetadataKey = "breaking-change"
 	IssueMetadataKey          = "issue"
+	RevertMetadataKey         = "revert"
+	RevertCommitType          = "revert"
 	MessageRegexGroupName     = "header"
+	IssueRegexGroupName       = "issue"
+	PRRegexGroupName          = "pr"
+	PRMetadataKey             = "pr"
 )
 
 var (
@@ -20,8 +30,62 @@ var (
 	errIssueIDNotFound      = errors.New("could not find issue id using configured regex")
 	errInvalidIssueRegex    = errors.New("could not compile issue regex")
 	errInvalidHeaderRegex   = errors.New("invalid regex on header-selector")
+	errInvalidPrefixRegex   = errors.New("invalid regex on description-prefix")
+	errInvalidValueRegex    = errors.New("invalid regex on footer value-regex")
+	errInvalidScopeValues   = errors.New("invalid scope values")
+	errInvalidScopeRegex    = errors.New("invalid regex on scope regex")
 )
 
+//nolint:gochecknoglobals
+var (
+	// revertSubjectRegex match the default subject of git revert, e.g. Revert "feat: something".
+	revertSubjectRegex = regexp.MustCompile(`^Revert "(.+)"$`)
+	// breakingHeaderRegex match a breaking change notation in the header.
+	breakingHeaderRegex = regexp.MustCompile(`(?i)BREAKING[- ]CHANGE`)
+	// breakingFooterRegex match breaking change footers with any case and separator.
+	breakingFooterRegex = regexp.MustCompile(`(?mi)^(BREAKING[- ]CHANGE):`)
+	// footerLineRegex match the start of a footer, e.g. "Refs: 1", "Refs #1" or "BREAKING CHANGE: breaks".
+	footerLineRegex = regexp.MustCompile("^(?:[a-zA-Z-]+: |[a-zA-Z-]+ #|" + BreakingChangeFooterKey + ": )")
+	// issueReferenceKeys footer keys used to reference closed issues, e.g. "Closes #1, #2".
+	issueReferenceKeys = []string{"Fixes", "Closes", "Resolves"}
+	// issueReferenceRegex match issue reference footers, e.g. "Closes #1, #2".
+	issueReferenceRegex = regexp.MustCompile(`(?mi)^(?:` + strings.Join(issueReferenceKeys, "|") + `):? +(.+)$`)
+	// issueTokenRegex match issue-like values, e.g. #123, owner/repo#123, ABC-1 or an issue URL.
+	issueTokenRegex = regexp.MustCompile(`^(?:(?:[\w.-]+/[\w.-]+)?#[0-9]+|[A-Z][A-Z0-9]*-[0-9]+|https?://\S+)$`)
+)
+
+// constants for ValidationError.Kind.
+const (
+	ValidationKindSubject     = "subject"
+	ValidationKindHeader      = "header"
+	ValidationKindType        = "type"
+	ValidationKindScope       = "scope"
+	ValidationKindDescription = "description"
+	ValidationKindBreaking    = "breaking-change"
+	ValidationKindEncoding    = "encoding"
+	ValidationKindFooter      = "footer"
+)
+
+// ValidationError commit message validation error with the failing field.
+type ValidationError struct {
+	Kind   string
+	Value  string
+	Reason string
+}
+
+func newValidationError(kind, value, reason string, args ...interface{}) *ValidationError {
+	return &ValidationError{Kind: kind, Value: value, Reason: fmt.Sprintf(reason, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", errInvalidCommitMessage.Error(), e.Reason)
+}
+
+// Unwrap keep errors.Is compatibility with the invalid commit message error.
+func (e *ValidationError) Unwrap() error {
+	return errInvalidCommitMessage
+}
+
 // CommitMessage is a message using conventional commits.
 type CommitMessage struct {
 	Type             string            `json:"type,omitempty"`
@@ -30,6 +94,7 @@ type CommitMessage struct {
 	Body             string            `json:"body,omitempty"`
 	IsBreakingChange bool              `json:"isBreakingChange,omitempty"`
 	Metadata         map[string]string `json:"metadata,omitempty"`
+	References       []string          `json:"references,omitempty"`
 }
 
 type CommitMessageConfig struct {
@@ -38,6 +103,25 @@ type CommitMessageConfig struct {
 	Scope          CommitMessageScopeConfig             `yaml:"scope"`
 	Footer         map[string]CommitMessageFooterConfig `yaml:"footer"`
 	Issue          CommitMessageIssueConfig             `yaml:"issue"`
+	// DescriptionPrefix regex of a prefix removed from the description, e.g. a ticket id like "[ABC-123]".
+	// A regex group 'issue' is stored as issue metadata if no issue footer is present.
+	DescriptionPrefix string `yaml:"description-prefix,omitempty"`
+	// MaxHeaderLength maximum length of the header (type, scope and description), 0 disables the check.
+	MaxHeaderLength int `yaml:"max-header-length,omitempty"`
+	// MinBreakingChangeLength minimum length of a breaking change footer message, 0 disables the check.
+	MinBreakingChangeLength int `yaml:"min-breaking-change-length,omitempty"`
+	// RequireFooterSeparator reject footers which are not separated from the body by a blank line.
+	RequireFooterSeparator bool `yaml:"require-footer-separator,omitempty"`
+	// Strict reject subjects containing trailing whitespace or tabs.
+	Strict bool `yaml:"strict,omitempty"`
+	// BreakingChangeFooters additional footers marking a commit as breaking change.
+	BreakingChangeFooters []CommitMessageBreakingChangeFooterConfig `yaml:"breaking-change-footers,omitempty"`
+}
+
+// CommitMessageBreakingChangeFooterConfig footer key and value marking a commit as breaking change.
+type CommitMessageBreakingChangeFooterConfig struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
 }
 
 // IssueFooterConfig config for issue.
@@ -52,6 +136,88 @@ func (c CommitMessageConfig) IssueFooterConfig() CommitMessageFooterConfig {
 // CommitMessageScopeConfig config scope preferences.
 type CommitMessageScopeConfig struct {
 	Values []string `yaml:"values"`
+	// Descriptions optional scope descriptions, set when values are defined as a map.
+	Descriptions map[string]string `yaml:"-"`
+	// FooterKey footer used as scope if the header has no scope, e.g. "Scope: api", empty disables it.
+	FooterKey string `yaml:"footer-key,omitempty"`
+	// Regex pattern a non empty scope must match if no values are defined, e.g. "^[a-z][a-z0-9-]*$".
+	Regex string `yaml:"regex,omitempty"`
+}
+
+// UnmarshalYAML accept scope values as list or as map of scope to description.
+func (c *CommitMessageScopeConfig) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Values    yaml.Node `yaml:"values"`
+		FooterKey string    `yaml:"footer-key"`
+		Regex     string    `yaml:"regex"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	c.FooterKey = raw.FooterKey
+	c.Regex = raw.Regex
+
+	switch raw.Values.Kind {
+	case yaml.MappingNode:
+		c.Values = make([]string, 0, len(raw.Values.Content)/2) //nolint:mnd
+		c.Descriptions = make(map[string]string)
+
+		for i := 0; i+1 < len(raw.Values.Content); i += 2 {
+			scope, description := raw.Values.Content[i].Value, raw.Values.Content[i+1].Value
+			c.Values = append(c.Values, scope)
+			c.Descriptions[scope] = description
+		}
+	case yaml.SequenceNode:
+		return raw.Values.Decode(&c.Values)
+	case 0:
+		// values not defined
+	default:
+		return fmt.Errorf("%w: scope values must be a list or a map", errInvalidScopeValues)
+	}
+
+	return nil
+}
+
+// MarshalYAML write scope values as map if descriptions are defined.
+func (c CommitMessageScopeConfig) MarshalYAML() (interface{}, error) {
+	if len(c.Descriptions) == 0 {
+		return struct {
+			Values    []string `yaml:"values"`
+			FooterKey string   `yaml:"footer-key,omitempty"`
+			Regex     string   `yaml:"regex,omitempty"`
+		}{Values: c.Values, FooterKey: c.FooterKey, Regex: c.Regex}, nil
+	}
+
+	values := &yaml.Node{Kind: yaml.MappingNode}
+	for _, scope := range c.Values {
+		values.Content = append(values.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: scope},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: c.Descriptions[scope]},
+		)
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "values"},
+		values,
+	}}
+
+	if c.FooterKey != "" {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "footer-key"},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: c.FooterKey},
+		)
+	}
+
+	if c.Regex != "" {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "regex"},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: c.Regex},
+		)
+	}
+
+	return node, nil
 }
 
 // CommitMessageFooterConfig config footer metadata.
@@ -60,11 +226,87 @@ type CommitMessageFooterConfig struct {
 	KeySynonyms    []string `yaml:"key-synonyms,flow"`
 	UseHash        bool     `yaml:"use-hash"`
 	AddValuePrefix string   `yaml:"add-value-prefix"`
+	// ParseUseHash overrides UseHash when footers are parsed, e.g. to accept "jira #JIRA-123".
+	ParseUseHash *bool `yaml:"parse-use-hash,omitempty"`
+	// FormatUseHash overrides UseHash when footers are rendered, e.g. to add "jira: JIRA-123".
+	FormatUseHash *bool `yaml:"format-use-hash,omitempty"`
+	// CaseInsensitiveKeys match footer keys regardless of their case.
+	CaseInsensitiveKeys bool `yaml:"case-insensitive-keys,omitempty"`
+	// ValueRegex regex the whole footer value must match if the footer is present, empty disables the check.
+	ValueRegex string `yaml:"value-regex,omitempty"`
+}
+
+// UseHashOnParse return if footers are parsed with <space># separator, ParseUseHash or UseHash.
+func (c CommitMessageFooterConfig) UseHashOnParse() bool {
+	if c.ParseUseHash != nil {
+		return *c.ParseUseHash
+	}
+
+	return c.UseHash
+}
+
+// UseHashOnFormat return if footers are rendered with <space># separator, FormatUseHash or UseHash.
+func (c CommitMessageFooterConfig) UseHashOnFormat() bool {
+	if c.FormatUseHash != nil {
+		return *c.FormatUseHash
+	}
+
+	return c.UseHash
 }
 
 // CommitMessageIssueConfig issue preferences.
 type CommitMessageIssueConfig struct {
-	Regex string `yaml:"regex"`
+	// Regexes issue id regexes tried in order, the first one matching the branch name is used.
+	// The yaml regex field accepts a single regex or a list of regexes.
+	Regexes []string `yaml:"regex"`
+	// Required fail the commit message hook if the message has no issue and none is found on the branch.
+	Required bool `yaml:"required,omitempty"`
+}
+
+// UnmarshalYAML accept the issue regex as single regex or as list of regexes.
+func (c *CommitMessageIssueConfig) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Regex    yaml.Node `yaml:"regex"`
+		Required bool      `yaml:"required"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	c.Required = raw.Required
+
+	switch raw.Regex.Kind {
+	case yaml.ScalarNode:
+		if raw.Regex.Value != "" {
+			c.Regexes = []string{raw.Regex.Value}
+		}
+	case yaml.SequenceNode:
+		return raw.Regex.Decode(&c.Regexes)
+	case 0:
+		// regex not defined
+	default:
+		return fmt.Errorf("%w: issue regex must be a string or a list", errInvalidIssueRegex)
+	}
+
+	return nil
+}
+
+// MarshalYAML write a single issue regex as string.
+func (c CommitMessageIssueConfig) MarshalYAML() (interface{}, error) {
+	type issueConfig struct {
+		Regex    interface{} `yaml:"regex"`
+		Required bool        `yaml:"required,omitempty"`
+	}
+
+	switch len(c.Regexes) {
+	case 0:
+		return issueConfig{Regex: "", Required: c.Required}, nil
+	case 1:
+		return issueConfig{Regex: c.Regexes[0], Required: c.Required}, nil
+	}
+
+	return issueConfig{Regex: c.Regexes, Required: c.Required}, nil
 }
 
 // BranchesConfig branches preferences.
@@ -74,6 +316,8 @@ type BranchesConfig struct {
 	DisableIssue bool     `yaml:"disable-issue"`
 	Skip         []string `yaml:"skip,flow"`
 	SkipDetached *bool    `yaml:"skip-detached"`
+	// SkipIssue regex patterns of branch names where no issue footer is added.
+	SkipIssue []string `yaml:"skip-issue,flow,omitempty"`
 }
 
 // NewCommitMessage commit message constructor.
@@ -102,6 +346,36 @@ func (m CommitMessage) Issue() string {
 	return m.Metadata[IssueMetadataKey]
 }
 
+// PR return the pull request number captured by the header selector.
+func (m CommitMessage) PR() string {
+	return m.Metadata[PRMetadataKey]
+}
+
+// IsRevert return true if the commit reverts a previous commit, either by using the revert type
+// or the default git revert subject.
+func (m CommitMessage) IsRevert() bool {
+	return m.Type == RevertCommitType || m.Metadata[RevertMetadataKey] != ""
+}
+
+// HasFooter return true if the commit has a footer with the given key, either as parsed metadata
+// key or as "key: value" or "key #value" line in the body.
+func (m CommitMessage) HasFooter(key string) bool {
+	if m.Metadata[key] != "" {
+		return true
+	}
+
+	for _, line := range strings.Split(m.Body, "\n") {
+		for _, separator := range []string{": ", " #"} {
+			value, found := strings.CutPrefix(line, key+separator)
+			if found && value != "" && !unicode.IsSpace(rune(value[0])) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // BreakingMessage return breaking change message from metadata.
 func (m CommitMessage) BreakingMessage() string {
 	return m.Metadata[BreakingChangeMetadataKey]
@@ -110,7 +384,9 @@ func (m CommitMessage) BreakingMessage() string {
 // MessageProcessor interface.
 type MessageProcessor interface {
 	SkipBranch(branch string, detached bool) bool
+	SkipIssueFooter(branch string) bool
 	Validate(message string) error
+	Warnings(message string) []string
 	ValidateType(ctype string) error
 	ValidateScope(scope string) error
 	ValidateDescription(description string) error
@@ -140,8 +416,23 @@ func (p BaseMessageProcessor) SkipBranch(branch string, detached bool) bool {
 		(p.branchesCfg.SkipDetached != nil && *p.branchesCfg.SkipDetached && detached)
 }
 
+// SkipIssueFooter return true if the branch matches any skip-issue pattern, invalid patterns are ignored.
+func (p BaseMessageProcessor) SkipIssueFooter(branch string) bool {
+	for _, pattern := range p.branchesCfg.SkipIssue {
+		if matched, err := regexp.MatchString("^(?:"+pattern+")$", branch); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Validate commit message.
 func (p BaseMessageProcessor) Validate(message string) error {
+	if err := validateEncoding(message); err != nil {
+		return err
+	}
+
 	subject, body := splitCommitMessageContent(message)
 	msg, parseErr := p.Parse(subject, body)
 
@@ -150,7 +441,29 @@ func (p BaseMessageProcessor) Validate(message string) error {
 	}
 
 	if !regexp.MustCompile(`^[a-z+]+(\(.+\))?!?: .+$`).MatchString(subject) {
-		return fmt.Errorf("%w: subject [%s] not valid", errInvalidCommitMessage, subject)
+		return newValidationError(ValidationKindSubject, subject, "subject [%s] not valid", subject)
+	}
+
+	if p.messageCfg.Strict && (strings.ContainsRune(subject, '\t') || strings.TrimRight(subject, " ") != subject) {
+		return newValidationError(
+			ValidationKindSubject, subject, "subject [%s] contains trailing whitespace or tabs", subject,
+		)
+	}
+
+	if err := p.validateHeaderLength(subject); err != nil {
+		return err
+	}
+
+	if err := p.validateBreakingChangeLength(body); err != nil {
+		return err
+	}
+
+	if err := p.validateFooterSeparator(body); err != nil {
+		return err
+	}
+
+	if err := p.validateFooterValues(msg); err != nil {
+		return err
 	}
 
 	if err := p.ValidateType(msg.Type); err != nil {
@@ -164,27 +477,197 @@ func (p BaseMessageProcessor) Validate(message string) error {
 	return p.ValidateDescription(msg.Description)
 }
 
+// Warnings return informational warnings for a commit message, e.g. deprecated breaking change notations.
+// Warnings never make a message invalid.
+func (p BaseMessageProcessor) Warnings(message string) []string {
+	return deprecatedForms(splitCommitMessageContent(message))
+}
+
+// validateEncoding reject messages which are not valid UTF-8, e.g. written with a legacy encoding.
+func validateEncoding(message string) error {
+	if utf8.ValidString(message) {
+		return nil
+	}
+
+	offset := 0
+	for offset < len(message) {
+		r, size := utf8.DecodeRuneInString(message[offset:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+
+		offset += size
+	}
+
+	return newValidationError(
+		ValidationKindEncoding, message, "message is not valid UTF-8, invalid byte at offset %d", offset,
+	)
+}
+
+// deprecatedForms return informational warnings for breaking change notations not following the
+// conventional commits specification.
+func deprecatedForms(subject, body string) []string {
+	var warnings []string
+
+	if breakingHeaderRegex.MatchString(subject) {
+		warnings = append(warnings, "breaking change in header is deprecated, use '!' after type/scope instead")
+	}
+
+	for _, match := range breakingFooterRegex.FindAllStringSubmatch(body, -1) {
+		if match[1] != BreakingChangeFooterKey && match[1] != "BREAKING-CHANGE" {
+			warnings = append(warnings, fmt.Sprintf("breaking change footer '%s' is not spec compliant, use '%s'",
+				match[1], BreakingChangeFooterKey))
+		}
+	}
+
+	return warnings
+}
+
+func (p BaseMessageProcessor) validateHeaderLength(subject string) error {
+	if p.messageCfg.MaxHeaderLength <= 0 {
+		return nil
+	}
+
+	header, _, err := p.prepareHeader(subject)
+	if err != nil {
+		return err
+	}
+
+	if length := utf8.RuneCountInString(header); length > p.messageCfg.MaxHeaderLength {
+		return newValidationError(
+			ValidationKindHeader, header,
+			"header [%s] has %d characters, max allowed is %d", header, length, p.messageCfg.MaxHeaderLength,
+		)
+	}
+
+	return nil
+}
+
+func (p BaseMessageProcessor) validateBreakingChangeLength(body string) error {
+	if p.messageCfg.MinBreakingChangeLength <= 0 {
+		return nil
+	}
+
+	footers := regexp.MustCompile(`(?m)^(?:BREAKING CHANGE|BREAKING-CHANGE):(.*)$`)
+
+	for _, match := range footers.FindAllStringSubmatch(body, -1) {
+		message := strings.TrimSpace(match[1])
+		if length := utf8.RuneCountInString(message); length < p.messageCfg.MinBreakingChangeLength {
+			return newValidationError(
+				ValidationKindBreaking, message, "breaking change [%s] has %d characters, min required is %d",
+				message, length, p.messageCfg.MinBreakingChangeLength,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateFooterSeparator check that the footers are a separate paragraph, i.e. the last paragraph
+// of the body does not mix body text and footers. Comment lines are ignored.
+func (p BaseMessageProcessor) validateFooterSeparator(body string) error {
+	if !p.messageCfg.RequireFooterSeparator {
+		return nil
+	}
+
+	footer := regexp.MustCompile(`^(?:BREAKING CHANGE|[A-Za-z][\w-]*)(?:: | #)`)
+
+	var paragraph []string
+
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		switch {
+		case strings.HasPrefix(line, "#"):
+		case strings.TrimSpace(line) == "":
+			paragraph = nil
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+
+	if len(paragraph) == 0 || footer.MatchString(paragraph[0]) {
+		return nil
+	}
+
+	for _, line := range paragraph[1:] {
+		if footer.MatchString(line) {
+			return newValidationError(
+				ValidationKindFooter, line, "footer [%s] must be separated from the body by a blank line", line,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateFooterValues check the value of each present footer against its configured value regex.
+func (p BaseMessageProcessor) validateFooterValues(msg CommitMessage) error {
+	keys := make([]string, 0, len(p.messageCfg.Footer))
+	for key := range p.messageCfg.Footer {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		footerCfg := p.messageCfg.Footer[key]
+
+		value, exists := msg.Metadata[key]
+		if footerCfg.ValueRegex == "" || !exists {
+			continue
+		}
+
+		matched, err := regexp.MatchString("^(?:"+footerCfg.ValueRegex+")$", value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", errInvalidValueRegex, footerCfg.ValueRegex, err.Error())
+		}
+
+		if !matched {
+			return newValidationError(
+				ValidationKindFooter, value, "footer %s value [%s] must match [%s]", footerCfg.Key, value,
+				footerCfg.ValueRegex,
+			)
+		}
+	}
+
+	return nil
+}
+
 // ValidateType check if commit type is valid.
 func (p BaseMessageProcessor) ValidateType(ctype string) error {
 	if ctype == "" || !contains(ctype, p.messageCfg.Types) {
-		return fmt.Errorf(
-			"%w: type must be one of [%s]",
-			errInvalidCommitMessage,
-			strings.Join(p.messageCfg.Types, ", "),
+		return newValidationError(
+			ValidationKindType, ctype, "type must be one of [%s]", strings.Join(p.messageCfg.Types, ", "),
 		)
 	}
 
 	return nil
 }
 
-// ValidateScope check if commit scope is valid.
+// ValidateScope check if commit scope is valid, values take precedence over regex.
 func (p BaseMessageProcessor) ValidateScope(scope string) error {
-	if len(p.messageCfg.Scope.Values) > 0 && !contains(scope, p.messageCfg.Scope.Values) {
-		return fmt.Errorf(
-			"%w: scope must one of [%s]",
-			errInvalidCommitMessage,
-			strings.Join(p.messageCfg.Scope.Values, ", "),
-		)
+	scopeCfg := p.messageCfg.Scope
+
+	if len(scopeCfg.Values) > 0 {
+		if !contains(scope, scopeCfg.Values) {
+			return newValidationError(
+				ValidationKindScope, scope, "scope must one of [%s]", strings.Join(scopeCfg.Values, ", "),
+			)
+		}
+
+		return nil
+	}
+
+	if scopeCfg.Regex == "" || scope == "" {
+		return nil
+	}
+
+	matched, err := regexp.MatchString("^(?:"+scopeCfg.Regex+")$", scope)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", errInvalidScopeRegex, scopeCfg.Regex, err.Error())
+	}
+
+	if !matched {
+		return newValidationError(ValidationKindScope, scope, "scope must match [%s]", scopeCfg.Regex)
 	}
 
 	return nil
@@ -193,7 +676,9 @@ func (p BaseMessageProcessor) ValidateScope(scope string) error {
 // ValidateDescription check if commit description is valid.
 func (p BaseMessageProcessor) ValidateDescription(description string) error {
 	if !regexp.MustCompile("^[a-z]+.*$").MatchString(description) {
-		return fmt.Errorf("%w: description [%s] must start with lowercase", errInvalidCommitMessage, description)
+		return newValidationError(
+			ValidationKindDescription, description, "description [%s] must start with lowercase", description,
+		)
 	}
 
 	return nil
@@ -202,7 +687,7 @@ func (p BaseMessageProcessor) ValidateDescription(description string) error {
 // Enhance add metadata on commit message.
 func (p BaseMessageProcessor) Enhance(branch, message string) (string, error) {
 	if p.branchesCfg.DisableIssue || p.messageCfg.IssueFooterConfig().Key == "" ||
-		hasIssueID(message, p.messageCfg.IssueFooterConfig()) {
+		p.SkipIssueFooter(branch) || hasIssueID(message, p.messageCfg.IssueFooterConfig()) {
 		return "", nil // enhance disabled
 	}
 
@@ -228,7 +713,7 @@ func formatIssueFooter(cfg CommitMessageFooterConfig, issue string) string {
 		issue = cfg.AddValuePrefix + issue
 	}
 
-	if cfg.UseHash {
+	if cfg.UseHashOnFormat() {
 		return fmt.Sprintf("%s #%s", cfg.Key, strings.TrimPrefix(issue, "#"))
 	}
 
@@ -237,23 +722,24 @@ func formatIssueFooter(cfg CommitMessageFooterConfig, issue string) string {
 
 // IssueID try to extract issue id from branch, return empty if not found.
 func (p BaseMessageProcessor) IssueID(branch string) (string, error) {
-	if p.branchesCfg.DisableIssue || p.messageCfg.Issue.Regex == "" {
+	if p.branchesCfg.DisableIssue || len(p.messageCfg.Issue.Regexes) == 0 {
 		return "", nil
 	}
 
-	rstr := fmt.Sprintf("^%s(%s)%s$", p.branchesCfg.Prefix, p.messageCfg.Issue.Regex, p.branchesCfg.Suffix)
+	for _, issueRegex := range p.messageCfg.Issue.Regexes {
+		rstr := fmt.Sprintf("^%s(%s)%s$", p.branchesCfg.Prefix, issueRegex, p.branchesCfg.Suffix)
 
-	r, err := regexp.Compile(rstr)
-	if err != nil {
-		return "", fmt.Errorf("%w: %s: %v", errInvalidIssueRegex, rstr, err.Error())
-	}
+		r, err := regexp.Compile(rstr)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", errInvalidIssueRegex, rstr, err.Error())
+		}
 
-	groups := r.FindStringSubmatch(branch)
-	if len(groups) != 4 { //nolint:mnd
-		return "", nil
+		if groups := r.FindStringSubmatch(branch); len(groups) == 4 { //nolint:mnd
+			return groups[2], nil
+		}
 	}
 
-	return groups[2], nil
+	return "", nil
 }
 
 // Format a commit message returning header, body and footer.
@@ -285,13 +771,27 @@ func (p BaseMessageProcessor) Format(msg CommitMessage) (string, string, string)
 	return header.String(), msg.Body, footer.String()
 }
 
+// JoinMessage assemble header, body and footer into a single commit message, empty parts
+// are skipped and the remaining ones are separated by a blank line.
+func JoinMessage(header, body, footer string) string {
+	var parts []string
+
+	for _, part := range []string{header, body, footer} {
+		if part = strings.TrimRight(part, "\n"); strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
 func removeCarriage(commit string) string {
 	return regexp.MustCompile(`\r`).ReplaceAllString(commit, "")
 }
 
 // Parse a commit message.
 func (p BaseMessageProcessor) Parse(subject, body string) (CommitMessage, error) {
-	preparedSubject, err := p.prepareHeader(subject)
+	preparedSubject, pr, err := p.prepareHeader(subject)
 	m := CommitMessage{}
 
 	if err != nil {
@@ -302,11 +802,33 @@ func (p BaseMessageProcessor) Parse(subject, body string) (CommitMessage, error)
 	m.Body = removeCarriage(body)
 	m.Type, m.Scope, m.Description, m.IsBreakingChange = parseSubjectMessage(preparedSubject)
 
+	description, prefixIssue, err := p.stripDescriptionPrefix(m.Description)
+	if err != nil {
+		return CommitMessage{}, err
+	}
+
+	m.Description = description
+
+	if m.Scope == "" && p.messageCfg.Scope.FooterKey != "" {
+		m.Scope = strings.TrimSpace(extractFooterMetadata(p.messageCfg.Scope.FooterKey, m.Body, false, false))
+	}
+
+	if pr != "" {
+		m.Metadata[PRMetadataKey] = pr
+	}
+
+	// a default git revert subject is not conventional, it is parsed as revert of the quoted subject
+	if result := revertSubjectRegex.FindStringSubmatch(preparedSubject); len(result) > 1 {
+		m.Type, m.Scope, m.Description, m.IsBreakingChange = RevertCommitType, "", result[1], false
+		m.Metadata[RevertMetadataKey] = result[1]
+	}
+
 	for key, mdCfg := range p.messageCfg.Footer {
 		if mdCfg.Key != "" {
 			prefixes := append([]string{mdCfg.Key}, mdCfg.KeySynonyms...)
 			for _, prefix := range prefixes {
-				if tagValue := extractFooterMetadata(prefix, m.Body, mdCfg.UseHash); tagValue != "" {
+				tagValue := extractFooterMetadata(prefix, m.Body, mdCfg.UseHashOnParse(), mdCfg.CaseInsensitiveKeys)
+				if tagValue != "" {
 					m.Metadata[key] = tagValue
 
 					break
@@ -315,37 +837,65 @@ func (p BaseMessageProcessor) Parse(subject, body string) (CommitMessage, error)
 		}
 	}
 
+	if _, exists := m.Metadata[IssueMetadataKey]; !exists && prefixIssue != "" {
+		m.Metadata[IssueMetadataKey] = prefixIssue
+	}
+
 	if m.IsBreakingChange {
 		m.Metadata[BreakingChangeMetadataKey] = m.Description
 	}
 
-	if tagValue := extractFooterMetadata(BreakingChangeFooterKey, m.Body, false); tagValue != "" {
+	if tagValue := extractFooterMetadata(BreakingChangeFooterKey, m.Body, false, false); tagValue != "" {
 		m.IsBreakingChange = true
 		m.Metadata[BreakingChangeMetadataKey] = tagValue
 	}
 
+	if !m.IsBreakingChange && p.hasBreakingChangeFooter(m.Body) {
+		m.IsBreakingChange = true
+		m.Metadata[BreakingChangeMetadataKey] = m.Description
+	}
+
+	m.References = extractIssueReferences(m.Body)
+
 	return m, nil
 }
 
-func (p BaseMessageProcessor) prepareHeader(header string) (string, error) {
+func (p BaseMessageProcessor) hasBreakingChangeFooter(body string) bool {
+	for _, footer := range p.messageCfg.BreakingChangeFooters {
+		if footer.Key == "" {
+			continue
+		}
+
+		value := strings.TrimSpace(extractFooterMetadata(footer.Key, body, false, false))
+		if value != "" && value == footer.Value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prepareHeader select the conventional header using the header selector, the optional group 'pr'
+// is returned as pull request number.
+func (p BaseMessageProcessor) prepareHeader(header string) (string, string, error) {
 	if p.messageCfg.HeaderSelector == "" {
-		return header, nil
+		return header, "", nil
 	}
 
 	regex, err := regexp.Compile(p.messageCfg.HeaderSelector)
 	if err != nil {
-		return "", fmt.Errorf("%w: %s: %s", errInvalidHeaderRegex, p.messageCfg.HeaderSelector, err.Error())
+		return "", "", fmt.Errorf("%w: %s: %s", errInvalidHeaderRegex, p.messageCfg.HeaderSelector, err.Error())
 	}
 
 	index := regex.SubexpIndex(MessageRegexGroupName)
 	if index < 0 {
-		return "", fmt.Errorf("%w: could not find group %s", errInvalidHeaderRegex, MessageRegexGroupName)
+		return "", "", fmt.Errorf("%w: could not find group %s", errInvalidHeaderRegex, MessageRegexGroupName)
 	}
 
 	match := regex.FindStringSubmatch(header)
 
 	if match == nil || len(match) < index {
-		return "", fmt.Errorf(
+		return "", "", fmt.Errorf(
 			"%w: could not find group %s in match result for '%s'",
 			errInvalidHeaderRegex,
 			MessageRegexGroupName,
@@ -353,7 +903,36 @@ func (p BaseMessageProcessor) prepareHeader(header string) (string, error) {
 		)
 	}
 
-	return match[index], nil
+	pr := ""
+	if prIndex := regex.SubexpIndex(PRRegexGroupName); prIndex >= 0 {
+		pr = match[prIndex]
+	}
+
+	return match[index], pr, nil
+}
+
+// stripDescriptionPrefix remove the configured prefix from the description and return the captured issue, if any.
+func (p BaseMessageProcessor) stripDescriptionPrefix(description string) (string, string, error) {
+	if p.messageCfg.DescriptionPrefix == "" {
+		return description, "", nil
+	}
+
+	regex, err := regexp.Compile(fmt.Sprintf(`^(?:%s)\s*`, p.messageCfg.DescriptionPrefix))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s: %s", errInvalidPrefixRegex, p.messageCfg.DescriptionPrefix, err.Error())
+	}
+
+	match := regex.FindStringSubmatch(description)
+	if match == nil || len(match[0]) == len(description) {
+		return description, "", nil
+	}
+
+	issue := ""
+	if index := regex.SubexpIndex(IssueRegexGroupName); index >= 0 {
+		issue = match[index]
+	}
+
+	return description[len(match[0]):], issue, nil
 }
 
 func parseSubjectMessage(message string) (string, string, string, bool) {
@@ -364,14 +943,14 @@ func parseSubjectMessage(message string) (string, string, string, bool) {
 		return "", "", message, false
 	}
 
-	return result[1], result[3], strings.TrimSpace(result[5]), result[4] == "!"
+	return result[1], strings.TrimSpace(result[3]), strings.TrimSpace(result[5]), result[4] == "!"
 }
 
-func extractFooterMetadata(key, text string, useHash bool) string {
-	regex := regexp.MustCompile(key + ": (.*)")
+func extractFooterMetadata(key, text string, useHash, caseInsensitive bool) string {
+	regex := regexp.MustCompile(footerFlags(caseInsensitive) + key + ": (.*)")
 
 	if useHash {
-		regex = regexp.MustCompile(key + " (#.*)")
+		regex = regexp.MustCompile(footerFlags(caseInsensitive) + key + " (#.*)")
 	}
 
 	result := regex.FindStringSubmatch(text)
@@ -382,31 +961,84 @@ func extractFooterMetadata(key, text string, useHash bool) string {
 	return result[1]
 }
 
-func hasFooter(message string) bool {
-	r := regexp.MustCompile("^[a-zA-Z-]+: .*|^[a-zA-Z-]+ #.*|^" + BreakingChangeFooterKey + ": .*")
+func footerFlags(caseInsensitive bool) string {
+	if caseInsensitive {
+		return "(?i)"
+	}
 
-	scanner := bufio.NewScanner(strings.NewReader(message))
-	lines := 0
+	return ""
+}
 
-	for scanner.Scan() {
-		if lines > 0 && r.MatchString(scanner.Text()) {
-			return true
+// extractIssueReferences return the issue-like values of the issue reference footers in the footer block
+// of the body, other words on the footer lines and references in the body prose are ignored.
+func extractIssueReferences(body string) []string {
+	var refs []string
+
+	for _, result := range issueReferenceRegex.FindAllStringSubmatch(footerBlock(body), -1) {
+		for _, ref := range strings.FieldsFunc(result[1], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			if issueTokenRegex.MatchString(ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// footerBlock return the footer block of a body, i.e. the last paragraph if it starts with a footer.
+// Comment lines are skipped, return empty if the body does not end with a footer block.
+func footerBlock(body string) string {
+	var paragraph []string
+
+	newParagraph := true
+
+	for _, line := range strings.Split(body, "\n") {
+		switch {
+		case strings.HasPrefix(line, "#"):
+		case strings.TrimSpace(line) == "":
+			newParagraph = true
+		case newParagraph:
+			paragraph = []string{line}
+			newParagraph = false
+		default:
+			paragraph = append(paragraph, line)
 		}
+	}
 
-		lines++
+	if len(paragraph) == 0 || !footerLineRegex.MatchString(paragraph[0]) {
+		return ""
 	}
 
-	return false
+	return strings.Join(paragraph, "\n")
+}
+
+// hasFooter check if the message ends with a footer block, i.e. the paragraph after the last blank
+// line starts with a footer. Footer-like lines in the middle of the body are ignored, comment lines
+// are skipped.
+func hasFooter(message string) bool {
+	_, body, _ := strings.Cut(message, "\n")
+
+	return footerBlock(body) != ""
 }
 
+// hasIssueID check if the message has an issue footer, in the parse or in the format style.
 func hasIssueID(message string, issueConfig CommitMessageFooterConfig) bool {
-	var r *regexp.Regexp
-	if issueConfig.UseHash {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s #.+$", issueConfig.Key))
-	} else {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s: .+$", issueConfig.Key))
+	flags := "(?m)"
+	if issueConfig.CaseInsensitiveKeys {
+		flags = "(?mi)"
+	}
+
+	separator := func(useHash bool) string {
+		if useHash {
+			return " #"
+		}
+
+		return ": "
 	}
 
+	r := regexp.MustCompile(fmt.Sprintf("%s^%s(?:%s|%s).+$", flags, issueConfig.Key,
+		separator(issueConfig.UseHashOnParse()), separator(issueConfig.UseHashOnFormat())))
+
 	return r.MatchString(message)
 }
 
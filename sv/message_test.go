@@ -1,8 +1,12 @@
 package sv
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 var ccfg = CommitMessageConfig{
@@ -12,7 +16,7 @@ var ccfg = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
 }
 
 var ccfgHash = CommitMessageConfig{
@@ -22,7 +26,7 @@ var ccfgHash = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}, UseHash: true},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
 }
 
 var ccfgGitIssue = CommitMessageConfig{
@@ -31,7 +35,7 @@ var ccfgGitIssue = CommitMessageConfig{
 	Footer: map[string]CommitMessageFooterConfig{
 		"issue": {Key: "issue", KeySynonyms: []string{"Issue"}, UseHash: false, AddValuePrefix: "#"},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "#?[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regexes: []string{"#?[0-9]+"}},
 }
 
 var ccfgEmptyIssue = CommitMessageConfig{
@@ -40,7 +44,12 @@ var ccfgEmptyIssue = CommitMessageConfig{
 	Footer: map[string]CommitMessageFooterConfig{
 		"issue": {},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgWithScopeRegex = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{Regex: "^[a-z][a-z0-9-]*$"},
 }
 
 var ccfgWithScope = CommitMessageConfig{
@@ -50,7 +59,14 @@ var ccfgWithScope = CommitMessageConfig{
 		"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
 		"refs":  {Key: "Refs", UseHash: true},
 	},
-	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+	Issue: CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
+}
+
+var ccfgBreakingFooter = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	BreakingChangeFooters: []CommitMessageBreakingChangeFooterConfig{
+		{Key: "Compatibility", Value: "broken"},
+	},
 }
 
 func newBranchCfg(skipDetached bool) BranchesConfig {
@@ -70,7 +86,7 @@ func newCommitMessageCfg(headerSelector string) CommitMessageConfig {
 			"issue": {Key: "jira", KeySynonyms: []string{"Jira"}},
 			"refs":  {Key: "Refs", UseHash: true},
 		},
-		Issue:          CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+		Issue:          CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}},
 		HeaderSelector: headerSelector,
 	}
 }
@@ -139,6 +155,55 @@ func TestBaseMessageProcessor_SkipBranch(t *testing.T) {
 	}
 }
 
+func TestBaseMessageProcessor_SkipIssueFooter(t *testing.T) {
+	bcfg := newBranchCfg(false)
+	bcfg.SkipIssue = []string{"release/.*", "hotfix", "[invalid"}
+
+	tests := []struct {
+		name   string
+		branch string
+		want   bool
+	}{
+		{"matching pattern", "release/JIRA-123", true},
+		{"exact name", "hotfix", true},
+		{"partial match", "hotfix-JIRA-123", false},
+		{"not matching", "feature/JIRA-123", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMessageProcessor(ccfg, bcfg).SkipIssueFooter(tt.branch); got != tt.want {
+				t.Errorf("BaseMessageProcessor.SkipIssueFooter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_EnhanceSkipIssue(t *testing.T) {
+	bcfg := newBranchCfg(false)
+	bcfg.SkipIssue = []string{"release/.*"}
+
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{"suppressed on matching branch", "release/JIRA-123", ""},
+		{"added on other branch", "feature/JIRA-123", "\njira: JIRA-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMessageProcessor(ccfg, bcfg).Enhance(tt.branch, "fix: fix something")
+			if err != nil {
+				t.Fatalf("BaseMessageProcessor.Enhance() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("BaseMessageProcessor.Enhance() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseMessageProcessor_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -158,6 +223,7 @@ func TestBaseMessageProcessor_Validate(t *testing.T) {
 		},
 		{"single line valid scope from list", ccfgWithScope, "feat(scope): add something", false},
 		{"single line invalid scope from list", ccfgWithScope, "feat(invalid): add something", true},
+		{"single line scope with whitespace from list", ccfgWithScope, "feat( scope ): add something", false},
 		{
 			"single line invalid type message",
 			ccfg,
@@ -206,6 +272,277 @@ func TestBaseMessageProcessor_Validate(t *testing.T) {
 	}
 }
 
+func TestBaseMessageProcessor_ValidateValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		wantKind  string
+		wantValue string
+	}{
+		{"invalid subject", "feat add something", ValidationKindSubject, "feat add something"},
+		{"invalid type", "docs: add something", ValidationKindType, "docs"},
+		{"invalid scope", "feat(invalid): add something", ValidationKindScope, "invalid"},
+		{"invalid description", "feat: Add something", ValidationKindDescription, "Add something"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(ccfgWithScope, newBranchCfg(false)).Validate(tt.message)
+			if !errors.Is(err, errInvalidCommitMessage) {
+				t.Fatalf("BaseMessageProcessor.Validate() error = %v, want %v", err, errInvalidCommitMessage)
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("BaseMessageProcessor.Validate() error = %T, want *ValidationError", err)
+			}
+
+			if verr.Kind != tt.wantKind || verr.Value != tt.wantValue {
+				t.Errorf("ValidationError = {%s %s}, want {%s %s}", verr.Kind, verr.Value, tt.wantKind, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr string
+	}{
+		{"valid utf-8", "feat: add something\n\nbody with ümlauts", ""},
+		{"invalid subject byte", "feat: add som\xe9thing", "invalid byte at offset 13"},
+		{"invalid body byte", "feat: add something\n\nbody \xff", "invalid byte at offset 26"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(ccfg, newBranchCfg(false)).Validate(tt.message)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("BaseMessageProcessor.Validate() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Kind != ValidationKindEncoding {
+				t.Fatalf("BaseMessageProcessor.Validate() error = %v, want %s validation error", err, ValidationKindEncoding)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, want to contain %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateMaxHeaderLength(t *testing.T) {
+	cfg := ccfgWithScope
+	cfg.MaxHeaderLength = 30
+
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"header at limit", "feat: add something to the app", false},
+		{"header with scope at limit", "feat(scope): add something new", false},
+		{"header with scope over limit", "feat(scope): add something newer", true},
+		{"header over limit", "feat: add something to the apps", true},
+		{"body ignored", "feat: add something\n\nbody line exceeding the max header length", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(cfg, newBranchCfg(false)).Validate(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var verr *ValidationError
+			if tt.wantErr && (!errors.As(err, &verr) || verr.Kind != ValidationKindHeader) {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, want kind %s", err, ValidationKindHeader)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateMinBreakingChangeLength(t *testing.T) {
+	cfg := ccfg
+	cfg.MinBreakingChangeLength = 10
+
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"without breaking change", "feat: add something", false},
+		{"breaking change with exclamation only", "feat!: add something", false},
+		{"empty breaking change", "feat: add something\n\nBREAKING CHANGE:", true},
+		{"blank breaking change", "feat: add something\n\nBREAKING CHANGE: ", true},
+		{"short breaking change", "feat: add something\n\nBREAKING-CHANGE: removed", true},
+		{"descriptive breaking change", "feat: add something\n\nBREAKING CHANGE: config key foo was removed", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(cfg, newBranchCfg(false)).Validate(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var verr *ValidationError
+			if tt.wantErr && (!errors.As(err, &verr) || verr.Kind != ValidationKindBreaking) {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, want kind %s", err, ValidationKindBreaking)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateFooterSeparator(t *testing.T) {
+	cfg := ccfg
+	cfg.RequireFooterSeparator = true
+
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		message string
+		wantErr bool
+	}{
+		{"without body", cfg, "feat: add something", false},
+		{"body without footer", cfg, "feat: add something\n\nsome body\nmore body", false},
+		{"footer only", cfg, "feat: add something\n\njira: JIRA-123\nRefs #133", false},
+		{"separated footer", cfg, "feat: add something\n\nsome body\n\njira: JIRA-123\nBREAKING CHANGE: removed", false},
+		{"footer glued to body", cfg, "feat: add something\n\nsome body\njira: JIRA-123", true},
+		{"hash footer glued to body", cfg, "feat: add something\n\nsome body\nRefs #133", true},
+		{"comments ignored", cfg, "feat: add something\n\nsome body\n\njira: JIRA-123\n# comment", false},
+		{"glued footer not required", ccfg, "feat: add something\n\nsome body\njira: JIRA-123", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Validate(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var verr *ValidationError
+			if tt.wantErr && (!errors.As(err, &verr) || verr.Kind != ValidationKindFooter) {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, want kind %s", err, ValidationKindFooter)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateFooterValues(t *testing.T) {
+	cfg := ccfg
+	cfg.Footer = map[string]CommitMessageFooterConfig{
+		"issue":       {Key: "jira"},
+		"reviewed-by": {Key: "Reviewed-by", ValueRegex: `.+ <[^@\s]+@[^@\s]+>`},
+	}
+
+	invalidRegex := cfg
+	invalidRegex.Footer = map[string]CommitMessageFooterConfig{"reviewed-by": {Key: "Reviewed-by", ValueRegex: "("}}
+
+	tests := []struct {
+		name     string
+		cfg      CommitMessageConfig
+		message  string
+		wantErr  bool
+		wantKind string
+	}{
+		{"without footer", cfg, "feat: add something\n\nsome body", false, ""},
+		{"matching value", cfg, "feat: add something\n\nReviewed-by: Jane Doe <jane@example.com>", false, ""},
+		{"value without email", cfg, "feat: add something\n\nReviewed-by: Jane Doe", true, ValidationKindFooter},
+		{
+			"partial match", cfg, "feat: add something\n\nReviewed-by: Jane <jane@example.com> later",
+			true, ValidationKindFooter,
+		},
+		{"footer without value regex", cfg, "feat: add something\n\njira: anything", false, ""},
+		{"invalid regex", invalidRegex, "feat: add something\n\nReviewed-by: Jane Doe", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Validate(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BaseMessageProcessor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var verr *ValidationError
+			if tt.wantKind != "" && (!errors.As(err, &verr) || verr.Kind != tt.wantKind) {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, want kind %s", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateStrict(t *testing.T) {
+	strict := ccfg
+	strict.Strict = true
+
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		message string
+		wantErr bool
+	}{
+		{"clean subject", strict, "feat: add something", false},
+		{"clean subject with body", strict, "feat: add something\n\nbody with trailing space ", false},
+		{"trailing spaces", strict, "feat: add something  ", true},
+		{"trailing tab", strict, "feat: add something\t", true},
+		{"inner tab", strict, "feat: add\tsomething", true},
+		{"trailing spaces without strict", ccfg, "feat: add something  ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Validate(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseMessageProcessor.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_deprecatedForms(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    int
+	}{
+		{"compliant message", "feat: add something", "", 0},
+		{"compliant breaking change", "feat!: add something", "BREAKING CHANGE: breaks", 0},
+		{"compliant breaking change synonym", "feat: add something", "BREAKING-CHANGE: breaks", 0},
+		{"breaking change in header", "feat: BREAKING-CHANGE add something", "", 1},
+		{"breaking change type", "BREAKING CHANGE: add something", "", 1},
+		{"lowercase breaking change footer", "feat: add something", "body\n\nBreaking change: breaks", 1},
+		{"both forms", "feat: breaking-change add something", "breaking-change: breaks", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deprecatedForms(tt.subject, tt.body); len(got) != tt.want {
+				t.Errorf("deprecatedForms() = %v, want %d warnings", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_Warnings(t *testing.T) {
+	p := NewMessageProcessor(ccfg, newBranchCfg(false))
+
+	if got := p.Warnings("feat: add something"); len(got) != 0 {
+		t.Errorf("BaseMessageProcessor.Warnings() = %v, want none", got)
+	}
+
+	message := "feat: add something BREAKING-CHANGE"
+
+	if err := p.Validate(message); err != nil {
+		t.Fatalf("BaseMessageProcessor.Validate() error = %v, want non-fatal warning", err)
+	}
+
+	got := p.Warnings(message)
+	if len(got) != 1 || !strings.Contains(got[0], "breaking change in header is deprecated") {
+		t.Errorf("BaseMessageProcessor.Warnings() = %v, want deprecation warning", got)
+	}
+}
+
 func TestBaseMessageProcessor_ValidateType(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -253,6 +590,20 @@ func TestBaseMessageProcessor_ValidateScope(t *testing.T) {
 		},
 		{"valid scope with scope list", ccfgWithScope, "scope", false},
 		{"invalid scope with scope list", ccfgWithScope, "aaa", true},
+		{"empty scope with scope regex", ccfgWithScopeRegex, "", false},
+		{"valid scope with scope regex", ccfgWithScopeRegex, "git-sv2", false},
+		{"invalid scope with scope regex", ccfgWithScopeRegex, "Git_SV", true},
+		{"partially matching scope with scope regex", ccfgWithScopeRegex, "api v2", true},
+		{
+			"scope list takes precedence over scope regex",
+			CommitMessageConfig{Scope: CommitMessageScopeConfig{Values: []string{"API"}, Regex: "^[a-z]+$"}},
+			"API", false,
+		},
+		{
+			"invalid scope regex",
+			CommitMessageConfig{Scope: CommitMessageScopeConfig{Regex: "[a-z"}},
+			"api", true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -264,6 +615,158 @@ func TestBaseMessageProcessor_ValidateScope(t *testing.T) {
 	}
 }
 
+func TestCommitMessageScopeConfig_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CommitMessageScopeConfig
+		wantErr bool
+	}{
+		{"list", "values: [\"\", api]", CommitMessageScopeConfig{Values: []string{"", "api"}}, false},
+		{
+			"map",
+			"values:\n  api: public api\n  cli: command line\n",
+			CommitMessageScopeConfig{
+				Values:       []string{"api", "cli"},
+				Descriptions: map[string]string{"api": "public api", "cli": "command line"},
+			},
+			false,
+		},
+		{"empty", "{}", CommitMessageScopeConfig{}, false},
+		{"invalid", "values: api", CommitMessageScopeConfig{}, true},
+		{
+			"list with footer key",
+			"values: [api]\nfooter-key: Scope\n",
+			CommitMessageScopeConfig{Values: []string{"api"}, FooterKey: "Scope"},
+			false,
+		},
+		{
+			"map with footer key",
+			"values:\n  api: public api\nfooter-key: Scope\n",
+			CommitMessageScopeConfig{
+				Values:       []string{"api"},
+				Descriptions: map[string]string{"api": "public api"},
+				FooterKey:    "Scope",
+			},
+			false,
+		},
+		{"regex", "regex: ^[a-z]+$", CommitMessageScopeConfig{Regex: "^[a-z]+$"}, false},
+		{
+			"map with regex",
+			"values:\n  api: public api\nregex: ^[a-z]+$\n",
+			CommitMessageScopeConfig{
+				Values:       []string{"api"},
+				Descriptions: map[string]string{"api": "public api"},
+				Regex:        "^[a-z]+$",
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CommitMessageScopeConfig
+
+			err := yaml.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CommitMessageScopeConfig.UnmarshalYAML() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitMessageScopeConfig.UnmarshalYAML() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantErr || len(got.Values) == 0 {
+				return
+			}
+
+			out, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatalf("CommitMessageScopeConfig.MarshalYAML() error = %v", err)
+			}
+
+			var roundtrip CommitMessageScopeConfig
+			if err := yaml.Unmarshal(out, &roundtrip); err != nil || !reflect.DeepEqual(roundtrip, got) {
+				t.Errorf("CommitMessageScopeConfig round trip = %v, want %v, error %v", roundtrip, got, err)
+			}
+		})
+	}
+}
+
+func TestCommitMessageIssueConfig_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CommitMessageIssueConfig
+		wantErr bool
+	}{
+		{"single regex", "regex: '[A-Z]+-[0-9]+'", CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+"}}, false},
+		{
+			"list of regexes",
+			"regex: ['[A-Z]+-[0-9]+', '#?[0-9]+']\nrequired: true\n",
+			CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+", "#?[0-9]+"}, Required: true},
+			false,
+		},
+		{"empty regex", "regex: ''", CommitMessageIssueConfig{}, false},
+		{"without regex", "required: true", CommitMessageIssueConfig{Required: true}, false},
+		{"invalid", "regex:\n  a: b\n", CommitMessageIssueConfig{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CommitMessageIssueConfig
+
+			err := yaml.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CommitMessageIssueConfig.UnmarshalYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitMessageIssueConfig.UnmarshalYAML() = %v, want %v", got, tt.want)
+			}
+
+			out, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatalf("CommitMessageIssueConfig.MarshalYAML() error = %v", err)
+			}
+
+			var roundtrip CommitMessageIssueConfig
+			if err := yaml.Unmarshal(out, &roundtrip); err != nil || !reflect.DeepEqual(roundtrip, got) {
+				t.Errorf("CommitMessageIssueConfig round trip = %v, want %v, error %v", roundtrip, got, err)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ValidateScopeFromYAML(t *testing.T) {
+	inputs := map[string]string{
+		"list": "values: [api]",
+		"map":  "values:\n  api: public api\n",
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			cfg := ccfg
+			if err := yaml.Unmarshal([]byte(input), &cfg.Scope); err != nil {
+				t.Fatal(err)
+			}
+
+			p := NewMessageProcessor(cfg, newBranchCfg(false))
+			if err := p.ValidateScope("api"); err != nil {
+				t.Errorf("BaseMessageProcessor.ValidateScope() error = %v", err)
+			}
+
+			if err := p.ValidateScope("other"); err == nil {
+				t.Errorf("BaseMessageProcessor.ValidateScope() expected error for unknown scope")
+			}
+		})
+	}
+}
+
 func TestBaseMessageProcessor_ValidateDescription(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -336,6 +839,11 @@ func TestBaseMessageProcessor_Enhance(t *testing.T) {
 			ccfg,
 			"JIRA-123", fullMessage, "jira: JIRA-123", false,
 		},
+		{
+			"with footer-like line in body",
+			ccfg,
+			"JIRA-123", "fix: fix something\n\nsee above\nnote: x\nfor details", "\njira: JIRA-123", false,
+		},
 		{
 			"with issue on footer",
 			ccfg,
@@ -425,6 +933,40 @@ func TestBaseMessageProcessor_IssueID(t *testing.T) {
 	}
 }
 
+func TestBaseMessageProcessor_IssueIDMultipleRegexes(t *testing.T) {
+	cfg := ccfg
+	cfg.Issue = CommitMessageIssueConfig{Regexes: []string{"[A-Z]+-[0-9]+", "#?[0-9]+"}}
+	p := NewMessageProcessor(cfg, newBranchCfg(false))
+
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{"first regex", "feature/JIRA-123-some-description", "JIRA-123"},
+		{"second regex", "feature/456-some-description", "456"},
+		{"second regex with hash", "#456", "#456"},
+		{"no regex matches", "feature/some-description", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.IssueID(tt.branch)
+			if err != nil {
+				t.Fatalf("BaseMessageProcessor.IssueID() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("BaseMessageProcessor.IssueID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	enhanced, err := p.Enhance("feature/456-some-description", "fix: fix something")
+	if err != nil || enhanced != "\njira: 456" {
+		t.Errorf("BaseMessageProcessor.Enhance() = %q, %v, want %q", enhanced, err, "\njira: 456")
+	}
+}
+
 const (
 	multilineBody = `a
 b
@@ -437,6 +979,8 @@ func Test_hasIssueID(t *testing.T) {
 	cfgColon := CommitMessageFooterConfig{Key: "jira"}
 	cfgHash := CommitMessageFooterConfig{Key: "jira", UseHash: true}
 	cfgEmpty := CommitMessageFooterConfig{}
+	cfgInsensitive := CommitMessageFooterConfig{Key: "jira", CaseInsensitiveKeys: true}
+	cfgInsensitiveHash := CommitMessageFooterConfig{Key: "jira", UseHash: true, CaseInsensitiveKeys: true}
 
 	tests := []struct {
 		name     string
@@ -460,6 +1004,15 @@ jira #JIRA-123`, cfgHash, true},
 		{"empty config", `feat: something
 
 jira #JIRA-123`, cfgEmpty, false},
+		{"case sensitive key", `feat: something
+
+Jira: JIRA-123`, cfgColon, false},
+		{"case insensitive key", `feat: something
+
+Jira: JIRA-123`, cfgInsensitive, true},
+		{"case insensitive key with hash", `feat: something
+
+JIRA #JIRA-123`, cfgInsensitiveHash, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -481,6 +1034,11 @@ func Test_hasFooter(t *testing.T) {
 		{"full messsage with refs", fullMessageRefs, true},
 		{"subject and footer message", subjectAndFooterMessage, true},
 		{"subject and body message", subjectAndBodyMessage, false},
+		{"footer-like line mid paragraph", "feat: add something\n\nsee above\nnote: x\nfor details", false},
+		{"footer-like paragraph before body", "feat: add something\n\nnote: x\n\nsee above", false},
+		{"footer-like line after body line", "feat: add something\n\nsee above\nnote: x", false},
+		{"footer block after body", "feat: add something\n\nnote: x in body\n\nRefs #133\nReviewed-by: Z", true},
+		{"footer block before comments", "feat: add something\n\nRefs #133\n\n# Please enter the message", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -646,6 +1204,29 @@ func TestBaseMessageProcessor_Parse(t *testing.T) {
 				Metadata:         map[string]string{},
 			},
 		},
+		{
+			"custom breaking change footer",
+			ccfgBreakingFooter,
+			"feat: something new", "Compatibility: broken",
+			CommitMessage{
+				Type:             "feat",
+				Description:      "something new",
+				Body:             "Compatibility: broken",
+				IsBreakingChange: true,
+				Metadata:         map[string]string{BreakingChangeMetadataKey: "something new"},
+			},
+		},
+		{
+			"custom breaking change footer with other value",
+			ccfgBreakingFooter,
+			"feat: something new", "Compatibility: kept",
+			CommitMessage{
+				Type:        "feat",
+				Description: "something new",
+				Body:        "Compatibility: kept",
+				Metadata:    map[string]string{},
+			},
+		},
 		{
 			"carriage return on body",
 			ccfg,
@@ -659,6 +1240,43 @@ func TestBaseMessageProcessor_Parse(t *testing.T) {
 				Metadata:         map[string]string{IssueMetadataKey: "JIRA-123"},
 			},
 		},
+		{
+			"case insensitive issue key",
+			CommitMessageConfig{
+				Types:  []string{"feat"},
+				Footer: map[string]CommitMessageFooterConfig{"issue": {Key: "jira", CaseInsensitiveKeys: true}},
+			},
+			"feat: something", "JIRA: JIRA-321",
+			CommitMessage{
+				Type:        "feat",
+				Description: "something",
+				Body:        "JIRA: JIRA-321",
+				Metadata:    map[string]string{IssueMetadataKey: "JIRA-321"},
+			},
+		},
+		{
+			"git revert message",
+			ccfg,
+			`Revert "feat: something"`, "This reverts commit abc123.",
+			CommitMessage{
+				Type:        RevertCommitType,
+				Description: "feat: something",
+				Body:        "This reverts commit abc123.",
+				Metadata:    map[string]string{RevertMetadataKey: "feat: something"},
+			},
+		},
+		{
+			"issue references on body",
+			ccfg,
+			"fix: something", "Closes #1, #2",
+			CommitMessage{
+				Type:        "fix",
+				Description: "something",
+				Body:        "Closes #1, #2",
+				Metadata:    map[string]string{},
+				References:  []string{"#1", "#2"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -671,6 +1289,179 @@ func TestBaseMessageProcessor_Parse(t *testing.T) {
 	}
 }
 
+func TestBaseMessageProcessor_ParseDescriptionPrefix(t *testing.T) {
+	cfg := ccfg
+	cfg.DescriptionPrefix = `\[(?P<issue>[A-Z]+-[0-9]+)\]`
+
+	noGroupCfg := ccfg
+	noGroupCfg.DescriptionPrefix = `\[[A-Z]+-[0-9]+\]`
+
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		subject string
+		body    string
+		want    CommitMessage
+	}{
+		{
+			"prefix captured as issue",
+			cfg,
+			"feat: [ABC-123] add thing", "",
+			CommitMessage{
+				Type:        "feat",
+				Description: "add thing",
+				Metadata:    map[string]string{"issue": "ABC-123"},
+			},
+		},
+		{
+			"issue footer takes precedence",
+			cfg,
+			"feat: [ABC-123] add thing", "jira: JIRA-1",
+			CommitMessage{
+				Type:        "feat",
+				Description: "add thing",
+				Body:        "jira: JIRA-1",
+				Metadata:    map[string]string{"issue": "JIRA-1"},
+			},
+		},
+		{
+			"prefix without issue group",
+			noGroupCfg,
+			"fix(api): [ABC-123] add thing", "",
+			CommitMessage{
+				Type:        "fix",
+				Scope:       "api",
+				Description: "add thing",
+				Metadata:    map[string]string{},
+			},
+		},
+		{
+			"without prefix",
+			cfg,
+			"feat: add thing [ABC-123]", "",
+			CommitMessage{
+				Type:        "feat",
+				Description: "add thing [ABC-123]",
+				Metadata:    map[string]string{},
+			},
+		},
+		{
+			"prefix only description is kept",
+			cfg,
+			"feat: [ABC-123]", "",
+			CommitMessage{
+				Type:        "feat",
+				Description: "[ABC-123]",
+				Metadata:    map[string]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Parse(tt.subject, tt.body)
+			if err != nil {
+				t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseMessageProcessor.Parse() = [%+v], want [%+v]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ParseScopeFooter(t *testing.T) {
+	cfg := ccfg
+	cfg.Scope.FooterKey = "Scope"
+
+	tests := []struct {
+		name      string
+		cfg       CommitMessageConfig
+		subject   string
+		body      string
+		wantScope string
+	}{
+		{"scope from footer", cfg, "feat: add thing", "some details\n\nScope: api", "api"},
+		{"header scope takes precedence", cfg, "feat(cli): add thing", "Scope: api", "cli"},
+		{"without footer", cfg, "feat: add thing", "some details", ""},
+		{"disabled", ccfg, "feat: add thing", "Scope: api", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Parse(tt.subject, tt.body)
+			if err != nil {
+				t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+			}
+
+			if got.Scope != tt.wantScope {
+				t.Errorf("BaseMessageProcessor.Parse() scope = %v, want %v", got.Scope, tt.wantScope)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ParseInvalidDescriptionPrefix(t *testing.T) {
+	cfg := ccfg
+	cfg.DescriptionPrefix = `[`
+
+	_, err := NewMessageProcessor(cfg, newBranchCfg(false)).Parse("feat: add thing", "")
+	if !errors.Is(err, errInvalidPrefixRegex) {
+		t.Errorf("BaseMessageProcessor.Parse() error = %v, want %v", err, errInvalidPrefixRegex)
+	}
+}
+
+func TestBaseMessageProcessor_FooterParseAndFormatUseHash(t *testing.T) {
+	useHash, useColon := true, false
+
+	tests := []struct {
+		name       string
+		footer     CommitMessageFooterConfig
+		body       string
+		wantFooter string
+	}{
+		{
+			"parse hash and format colon",
+			CommitMessageFooterConfig{Key: "issue", ParseUseHash: &useHash, FormatUseHash: &useColon},
+			"issue #13", "issue: #13",
+		},
+		{
+			"parse colon and format hash",
+			CommitMessageFooterConfig{Key: "issue", UseHash: true, ParseUseHash: &useColon},
+			"issue: #13", "issue #13",
+		},
+		{
+			"use hash for both",
+			CommitMessageFooterConfig{Key: "issue", UseHash: true},
+			"issue #13", "issue #13",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ccfg
+			cfg.Footer = map[string]CommitMessageFooterConfig{IssueMetadataKey: tt.footer}
+			p := NewMessageProcessor(cfg, newBranchCfg(false))
+
+			msg, err := p.Parse("feat: something", tt.body)
+			if err != nil {
+				t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+			}
+
+			if got := msg.Issue(); got != "#13" {
+				t.Errorf("BaseMessageProcessor.Parse() issue = %v, want #13", got)
+			}
+
+			if _, _, got := p.Format(NewCommitMessage("feat", "", "something", "", msg.Issue(), "")); got != tt.wantFooter {
+				t.Errorf("BaseMessageProcessor.Format() footer = %v, want %v", got, tt.wantFooter)
+			}
+
+			enhanced, err := p.Enhance("13", "feat: something\n\n"+tt.wantFooter)
+			if err != nil || enhanced != "" {
+				t.Errorf("BaseMessageProcessor.Enhance() = %q, %v, want no footer added", enhanced, err)
+			}
+		})
+	}
+}
+
 func TestBaseMessageProcessor_Format(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -827,6 +1618,82 @@ func Test_splitCommitMessageContent(t *testing.T) {
 	}
 }
 
+func TestCommitMessage_IsRevert(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  CommitMessage
+		want bool
+	}{
+		{"revert type", CommitMessage{Type: "revert"}, true},
+		{"git revert", CommitMessage{Type: "feat", Metadata: map[string]string{RevertMetadataKey: "feat: x"}}, true},
+		{"no revert", CommitMessage{Type: "feat", Metadata: map[string]string{}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.IsRevert(); got != tt.want {
+				t.Errorf("CommitMessage.IsRevert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessage_HasFooter(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  CommitMessage
+		key  string
+		want bool
+	}{
+		{"footer in body", CommitMessage{Body: "some body\n\nSecurity-Review: alice"}, "Security-Review", true},
+		{"footer with hash", CommitMessage{Body: "Refs #133"}, "Refs", true},
+		{"metadata key", CommitMessage{Metadata: map[string]string{IssueMetadataKey: "JIRA-1"}}, IssueMetadataKey, true},
+		{"missing footer", CommitMessage{Body: "Reviewed-by: bob"}, "Security-Review", false},
+		{"key inside text", CommitMessage{Body: "see Security-Review: later"}, "Security-Review", false},
+		{"empty value", CommitMessage{Body: "Security-Review: "}, "Security-Review", false},
+		{"space value", CommitMessage{Body: "Security-Review:  alice"}, "Security-Review", false},
+		{"regex characters in key", CommitMessage{Body: "Refs #133"}, "R.fs", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.HasFooter(tt.key); got != tt.want {
+				t.Errorf("CommitMessage.HasFooter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractIssueReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"no references", "some description", nil},
+		{"single reference", "Fixes #1", []string{"#1"}},
+		{"multiple references", "Closes #1, #2", []string{"#1", "#2"}},
+		{"reference with colon", "Resolves: #3", []string{"#3"}},
+		{"multiple keys", "some description\n\nFixes #1\nCloses #2, #3", []string{"#1", "#2", "#3"}},
+		{"lowercase key", "closes #4", []string{"#4"}},
+		{"key inside sentence", "this closes #5", nil},
+		{"key in body prose", "Fixes the parser for nested scopes.\n\nRefs: #1", nil},
+		{"key in body before footer", "Closes #6 later\n\nRefs: #1", nil},
+		{
+			"issue key and url", "body\n\nFixes: ABC-1, https://example.com/issues/7",
+			[]string{"ABC-1", "https://example.com/issues/7"},
+		},
+		{"repository reference", "Closes: owner/repo#8", []string{"owner/repo#8"}},
+		{"prose after reference", "Fixes #9 in the parser", []string{"#9"}},
+		{"footer with continuation", "Refs: #1\nCloses #10", []string{"#10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractIssueReferences(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractIssueReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseSubjectMessage(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -840,6 +1707,7 @@ func Test_parseSubjectMessage(t *testing.T) {
 		{"valid commit with scope", "feat(scope): something", "feat", "scope", "something", false},
 		{"valid commit with breaking change", "feat(scope)!: something", "feat", "scope", "something", true},
 		{"missing description", "feat: ", "feat", "", "", false},
+		{"scope with whitespace", "feat( api ): x", "feat", "api", "x", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -930,7 +1798,7 @@ func Test_prepareHeader(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			msgProcessor := NewMessageProcessor(newCommitMessageCfg(tt.headerSelector), newBranchCfg(false))
-			header, err := msgProcessor.prepareHeader(tt.commitHeader)
+			header, _, err := msgProcessor.prepareHeader(tt.commitHeader)
 
 			if tt.wantError && err == nil {
 				t.Errorf("prepareHeader() err got = %v, want not nil", err)
@@ -943,6 +1811,48 @@ func Test_prepareHeader(t *testing.T) {
 	}
 }
 
+func Test_prepareHeaderPR(t *testing.T) {
+	tests := []struct {
+		name           string
+		headerSelector string
+		commitHeader   string
+		wantHeader     string
+		wantPR         string
+	}{
+		{"without selector", "", "feat: something (#12)", "feat: something (#12)", ""},
+		{"selector without pr group", `^(?P<header>.+) \(#\d+\)$`, "feat: something (#12)", "feat: something", ""},
+		{"selector with pr group", `^(?P<header>.+) \(#(?P<pr>\d+)\)$`, "feat: something (#12)", "feat: something", "12"},
+		{"pr group before header", `^Merged PR (?P<pr>\d+): (?P<header>.*)`, "Merged PR 123: feat: x", "feat: x", "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgProcessor := NewMessageProcessor(newCommitMessageCfg(tt.headerSelector), newBranchCfg(false))
+
+			header, pr, err := msgProcessor.prepareHeader(tt.commitHeader)
+			if err != nil {
+				t.Fatalf("prepareHeader() error = %v", err)
+			}
+
+			if header != tt.wantHeader || pr != tt.wantPR {
+				t.Errorf("prepareHeader() = %v, %v, want %v, %v", header, pr, tt.wantHeader, tt.wantPR)
+			}
+		})
+	}
+}
+
+func TestBaseMessageProcessor_ParsePR(t *testing.T) {
+	cfg := newCommitMessageCfg(`^(?P<header>.+) \(#(?P<pr>\d+)\)$`)
+
+	got, err := NewMessageProcessor(cfg, newBranchCfg(false)).Parse("feat(scope): add something (#42)", "")
+	if err != nil {
+		t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+	}
+
+	if got.Type != "feat" || got.Scope != "scope" || got.Description != "add something" || got.PR() != "42" {
+		t.Errorf("BaseMessageProcessor.Parse() = %+v, want feat(scope) with pr 42", got)
+	}
+}
+
 func Test_removeCarriage(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -961,3 +1871,32 @@ func Test_removeCarriage(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		footer string
+		want   string
+	}{
+		{"header only", "feat: something", "", "", "feat: something"},
+		{"header and body", "feat: something", "body", "", "feat: something\n\nbody"},
+		{"header and footer", "feat: something", "", "jira: JIRA-123", "feat: something\n\njira: JIRA-123"},
+		{
+			"full message",
+			"feat: something",
+			"body line 1\nbody line 2\n",
+			"BREAKING CHANGE: breaks\njira: JIRA-123",
+			"feat: something\n\nbody line 1\nbody line 2\n\nBREAKING CHANGE: breaks\njira: JIRA-123",
+		},
+		{"blank body", "feat: something", " \n", "jira: JIRA-123", "feat: something\n\njira: JIRA-123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JoinMessage(tt.header, tt.body, tt.footer); got != tt.want {
+				t.Errorf("JoinMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
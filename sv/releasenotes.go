@@ -1,6 +1,8 @@
 package sv
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -8,7 +10,19 @@ import (
 
 // ReleaseNotesConfig release notes preferences.
 type ReleaseNotesConfig struct {
-	Sections []ReleaseNotesSectionConfig `yaml:"sections"`
+	Sections     []ReleaseNotesSectionConfig `yaml:"sections"`
+	EmptyMessage string                      `yaml:"empty-message,omitempty"`
+	// CommitURLTemplate url used to link commits, CommitURLHashPlaceholder is replaced by the commit hash.
+	CommitURLTemplate string `yaml:"commit-url-template,omitempty"`
+	// CompareURLTemplate url used to compare two tags, CompareURLFromPlaceholder and
+	// CompareURLToPlaceholder are replaced by the previous and the current tag.
+	CompareURLTemplate string `yaml:"compare-url-template,omitempty"`
+	// SectionOrder section names in the order they are rendered, sections not listed keep their
+	// configured order after the listed ones. If empty, the order of Sections is used.
+	SectionOrder []string `yaml:"section-order,omitempty"`
+	// CollapseThreshold commits sections with more items are rendered collapsed, e.g. in a html details
+	// block of the markdown templates, 0 disables it.
+	CollapseThreshold int `yaml:"collapse-threshold,omitempty"`
 }
 
 func (cfg ReleaseNotesConfig) sectionConfig(sectionType string) *ReleaseNotesSectionConfig {
@@ -21,11 +35,71 @@ func (cfg ReleaseNotesConfig) sectionConfig(sectionType string) *ReleaseNotesSec
 	return nil
 }
 
+// OrderedSections return the sections config sorted by SectionOrder.
+func (cfg ReleaseNotesConfig) OrderedSections() []ReleaseNotesSectionConfig {
+	if len(cfg.SectionOrder) == 0 {
+		return cfg.Sections
+	}
+
+	position := func(section ReleaseNotesSectionConfig) int {
+		if i := slices.Index(cfg.SectionOrder, section.Name); i >= 0 {
+			return i
+		}
+
+		return len(cfg.SectionOrder)
+	}
+
+	sections := slices.Clone(cfg.Sections)
+	slices.SortStableFunc(sections, func(a, b ReleaseNotesSectionConfig) int {
+		return position(a) - position(b)
+	})
+
+	return sections
+}
+
+// Compare return the compare url between two tags, if no compare url template is configured
+// the range from...to is returned instead.
+func (cfg ReleaseNotesConfig) Compare(from, to string) string {
+	if cfg.CompareURLTemplate == "" {
+		return from + "..." + to
+	}
+
+	return strings.NewReplacer(CompareURLFromPlaceholder, from, CompareURLToPlaceholder, to).
+		Replace(cfg.CompareURLTemplate)
+}
+
+// DuplicatedCommitTypes return commit types mapped by more than one commits section, with the section names.
+func (cfg ReleaseNotesConfig) DuplicatedCommitTypes() map[string][]string {
+	sectionNames := make(map[string][]string)
+
+	for _, section := range cfg.Sections {
+		if section.SectionType != ReleaseNotesSectionTypeCommits {
+			continue
+		}
+
+		for _, commitType := range section.CommitTypes {
+			sectionNames[commitType] = append(sectionNames[commitType], section.Name)
+		}
+	}
+
+	for commitType, names := range sectionNames {
+		if len(names) < 2 { //nolint:mnd
+			delete(sectionNames, commitType)
+		}
+	}
+
+	return sectionNames
+}
+
 // ReleaseNotesSectionConfig preferences for a single section on release notes.
 type ReleaseNotesSectionConfig struct {
 	Name        string   `yaml:"name"`
 	SectionType string   `yaml:"section-type"`
 	CommitTypes []string `yaml:"commit-types,flow,omitempty"`
+	ShowHash    bool     `yaml:"show-hash,omitempty"`
+	Emoji       string   `yaml:"emoji,omitempty"`
+	// CollapseIssues render each issue reference only once within the section.
+	CollapseIssues bool `yaml:"collapse-issues,omitempty"`
 }
 
 const (
@@ -33,6 +107,16 @@ const (
 	ReleaseNotesSectionTypeCommits = "commits"
 	// ReleaseNotesSectionTypeBreakingChanges ReleaseNotesSectionConfig.SectionType value.
 	ReleaseNotesSectionTypeBreakingChanges = "breaking-changes"
+	// ReleaseNotesSectionTypeOther ReleaseNotesSectionConfig.SectionType value.
+	ReleaseNotesSectionTypeOther = "other"
+	// ReleaseNotesSectionTypeReverts ReleaseNotesSectionConfig.SectionType value.
+	ReleaseNotesSectionTypeReverts = "reverts"
+	// CommitURLHashPlaceholder ReleaseNotesConfig.CommitURLTemplate placeholder for the commit hash.
+	CommitURLHashPlaceholder = "{hash}"
+	// CompareURLFromPlaceholder ReleaseNotesConfig.CompareURLTemplate placeholder for the previous tag.
+	CompareURLFromPlaceholder = "{from}"
+	// CompareURLToPlaceholder ReleaseNotesConfig.CompareURLTemplate placeholder for the current tag.
+	CompareURLToPlaceholder = "{to}"
 )
 
 // ReleaseNoteProcessor release note processor interface.
@@ -58,19 +142,42 @@ func (p BaseReleaseNoteProcessor) Create(
 	commits []CommitLog,
 ) ReleaseNote {
 	mapping := commitSectionMapping(p.cfg.Sections)
+	otherCfg := p.cfg.sectionConfig(ReleaseNotesSectionTypeOther)
+	revertsCfg := p.cfg.sectionConfig(ReleaseNotesSectionTypeReverts)
 
 	sections := make(map[string]ReleaseNoteCommitsSection)
 	authors := make(map[string]struct{})
+	emails := make(map[string]string)
 
-	var breakingChanges []string
+	var breakingChanges []CommitLog
 
 	for _, commit := range commits {
 		authors[commit.AuthorName] = struct{}{}
 
-		if sectionCfg, exists := mapping[commit.Message.Type]; exists {
+		if commit.AuthorEmail != "" {
+			emails[commit.AuthorName] = commit.AuthorEmail
+		}
+
+		sectionCfg, exists := mapping[commit.Message.Type]
+
+		switch {
+		case revertsCfg != nil && commit.Message.IsRevert():
+			sectionCfg, exists = *revertsCfg, true
+		case !exists && otherCfg != nil:
+			sectionCfg, exists = *otherCfg, true
+		}
+
+		if exists {
 			section, sexists := sections[sectionCfg.Name]
 			if !sexists {
-				section = ReleaseNoteCommitsSection{Name: sectionCfg.Name, Types: sectionCfg.CommitTypes}
+				section = ReleaseNoteCommitsSection{
+					Name:              sectionCfg.Name,
+					Types:             sectionCfg.CommitTypes,
+					CollapseIssues:    sectionCfg.CollapseIssues,
+					Emoji:             sectionCfg.Emoji,
+					URLTemplate:       p.cfg.CommitURLTemplate,
+					CollapseThreshold: p.cfg.CollapseThreshold,
+				}
 			}
 
 			section.Items = append(section.Items, commit)
@@ -78,21 +185,23 @@ func (p BaseReleaseNoteProcessor) Create(
 		}
 
 		if commit.Message.IsBreakingChange {
-			breakingChanges = append(breakingChanges, commit.Message.BreakingMessage())
+			breakingChanges = append(breakingChanges, commit)
 		}
 	}
 
 	var breakingChangeSection ReleaseNoteBreakingChangeSection
 	if bcCfg := p.cfg.sectionConfig(ReleaseNotesSectionTypeBreakingChanges); bcCfg != nil && len(breakingChanges) > 0 {
-		breakingChangeSection = ReleaseNoteBreakingChangeSection{Name: bcCfg.Name, Messages: breakingChanges}
+		breakingChangeSection = newBreakingChangeSection(*bcCfg, breakingChanges)
 	}
 
 	return ReleaseNote{
-		Version:      version,
-		Tag:          tag,
-		Date:         date.Truncate(time.Minute),
-		Sections:     p.toReleaseNoteSections(sections, breakingChangeSection),
-		AuthorsNames: authors,
+		Version:       version,
+		Tag:           tag,
+		Date:          date.Truncate(time.Minute),
+		Sections:      p.toReleaseNoteSections(sections, breakingChangeSection),
+		AuthorsNames:  authors,
+		AuthorsEmails: emails,
+		EmptyMessage:  p.cfg.EmptyMessage,
 	}
 }
 
@@ -108,13 +217,13 @@ func (p BaseReleaseNoteProcessor) toReleaseNoteSections(
 	sections := make([]ReleaseNoteSection, len(commitSections)+hasBreaking)
 	i := 0
 
-	for _, cfg := range p.cfg.Sections {
+	for _, cfg := range p.cfg.OrderedSections() {
 		if cfg.SectionType == ReleaseNotesSectionTypeBreakingChanges && hasBreaking > 0 {
 			sections[i] = breakingChange
 			i++
 		}
 
-		if s, exists := commitSections[cfg.Name]; cfg.SectionType == ReleaseNotesSectionTypeCommits && exists {
+		if s, exists := commitSections[cfg.Name]; isCommitsSectionType(cfg.SectionType) && exists {
 			sections[i] = s
 			i++
 		}
@@ -123,6 +232,44 @@ func (p BaseReleaseNoteProcessor) toReleaseNoteSections(
 	return sections
 }
 
+func isCommitsSectionType(sectionType string) bool {
+	return sectionType == ReleaseNotesSectionTypeCommits ||
+		sectionType == ReleaseNotesSectionTypeOther ||
+		sectionType == ReleaseNotesSectionTypeReverts
+}
+
+// DroppedCommits return the commits not rendered on any section because their type
+// is not mapped to a commits section and they are not listed as breaking change.
+func (cfg ReleaseNotesConfig) DroppedCommits(commits []CommitLog) []CommitLog {
+	if cfg.sectionConfig(ReleaseNotesSectionTypeOther) != nil {
+		return nil
+	}
+
+	mapping := commitSectionMapping(cfg.Sections)
+	hasBreaking := cfg.sectionConfig(ReleaseNotesSectionTypeBreakingChanges) != nil
+	hasReverts := cfg.sectionConfig(ReleaseNotesSectionTypeReverts) != nil
+
+	var dropped []CommitLog
+
+	for _, commit := range commits {
+		if _, exists := mapping[commit.Message.Type]; exists {
+			continue
+		}
+
+		if hasReverts && commit.Message.IsRevert() {
+			continue
+		}
+
+		if hasBreaking && commit.Message.IsBreakingChange {
+			continue
+		}
+
+		dropped = append(dropped, commit)
+	}
+
+	return dropped
+}
+
 func commitSectionMapping(sections []ReleaseNotesSectionConfig) map[string]ReleaseNotesSectionConfig {
 	mapping := make(map[string]ReleaseNotesSectionConfig)
 
@@ -144,6 +291,100 @@ type ReleaseNote struct {
 	Date         time.Time
 	Sections     []ReleaseNoteSection
 	AuthorsNames map[string]struct{}
+	// AuthorsEmails maps author names to their email, authors without email are omitted.
+	AuthorsEmails map[string]string
+	EmptyMessage  string
+	// Bump type compared to the previous release: major, minor, patch or empty.
+	Bump string
+	// Compare link or range between the previous and the current tag, empty if there is no previous tag.
+	Compare string
+	// Stats aggregated file changes of the release commits, nil if not requested.
+	Stats *CommitStats
+}
+
+// BreakingChangesOnly return a copy of the release note containing only the breaking changes section.
+func (n ReleaseNote) BreakingChangesOnly() ReleaseNote {
+	sections := make([]ReleaseNoteSection, 0, 1)
+
+	for _, section := range n.Sections {
+		if section.SectionType() == ReleaseNotesSectionTypeBreakingChanges {
+			sections = append(sections, section)
+		}
+	}
+
+	n.Sections = sections
+
+	return n
+}
+
+// MergeReleaseNotes combine the release notes of multiple sources, e.g. repositories, into a single
+// release note. Sections with the same type and name are concatenated in order of appearance and
+// authors are deduplicated, version, tag, date and messages are taken from the first release note.
+func MergeReleaseNotes(notes ...ReleaseNote) ReleaseNote {
+	if len(notes) == 0 {
+		return ReleaseNote{}
+	}
+
+	result := notes[0]
+	result.Sections = nil
+	result.AuthorsNames = make(map[string]struct{})
+	result.AuthorsEmails = make(map[string]string)
+
+	positions := make(map[string]int)
+
+	for _, note := range notes {
+		for name := range note.AuthorsNames {
+			result.AuthorsNames[name] = struct{}{}
+		}
+
+		for name, email := range note.AuthorsEmails {
+			if _, exists := result.AuthorsEmails[name]; !exists {
+				result.AuthorsEmails[name] = email
+			}
+		}
+
+		for _, section := range note.Sections {
+			key := section.SectionType() + "/" + section.SectionName()
+
+			if i, exists := positions[key]; exists {
+				result.Sections[i] = mergeSections(result.Sections[i], section)
+
+				continue
+			}
+
+			positions[key] = len(result.Sections)
+			result.Sections = append(result.Sections, section)
+		}
+	}
+
+	return result
+}
+
+func mergeSections(section, other ReleaseNoteSection) ReleaseNoteSection { //nolint:ireturn
+	switch s := section.(type) {
+	case ReleaseNoteCommitsSection:
+		if o, ok := other.(ReleaseNoteCommitsSection); ok {
+			s.Items = append(slices.Clone(s.Items), o.Items...)
+			s.Types = slices.Clone(s.Types)
+
+			for _, commitType := range o.Types {
+				if !slices.Contains(s.Types, commitType) {
+					s.Types = append(s.Types, commitType)
+				}
+			}
+		}
+
+		return s
+	case ReleaseNoteBreakingChangeSection:
+		if o, ok := other.(ReleaseNoteBreakingChangeSection); ok {
+			s.Messages = append(slices.Clone(s.Messages), o.Messages...)
+			s.Items = append(slices.Clone(s.Items), o.Items...)
+		}
+
+		return s
+	}
+
+	return section
 }
 
 // ReleaseNoteSection section in release notes.
@@ -156,6 +397,24 @@ type ReleaseNoteSection interface {
 type ReleaseNoteBreakingChangeSection struct {
 	Name     string
 	Messages []string
+	Items    []CommitLog
+	ShowHash bool
+	Emoji    string
+}
+
+func newBreakingChangeSection(cfg ReleaseNotesSectionConfig, commits []CommitLog) ReleaseNoteBreakingChangeSection {
+	messages := make([]string, len(commits))
+	for i, commit := range commits {
+		messages[i] = commit.Message.BreakingMessage()
+	}
+
+	return ReleaseNoteBreakingChangeSection{
+		Name:     cfg.Name,
+		Messages: messages,
+		Items:    commits,
+		ShowHash: cfg.ShowHash,
+		Emoji:    cfg.Emoji,
+	}
 }
 
 // SectionType section type.
@@ -170,9 +429,14 @@ func (s ReleaseNoteBreakingChangeSection) SectionName() string {
 
 // ReleaseNoteCommitsSection release note section.
 type ReleaseNoteCommitsSection struct {
-	Name  string
-	Types []string
-	Items []CommitLog
+	Name           string
+	Types          []string
+	Items          []CommitLog
+	CollapseIssues bool
+	Emoji          string
+	URLTemplate    string
+	// CollapseThreshold sections with more items are rendered collapsed, 0 disables it.
+	CollapseThreshold int
 }
 
 // SectionType section type.
@@ -185,7 +449,74 @@ func (s ReleaseNoteCommitsSection) SectionName() string {
 	return s.Name
 }
 
+// Collapsed return true if the section has more items than the collapse threshold.
+func (s ReleaseNoteCommitsSection) Collapsed() bool {
+	return s.CollapseThreshold > 0 && len(s.Items) > s.CollapseThreshold
+}
+
 // HasMultipleTypes return true if has more than one commit type.
 func (s ReleaseNoteCommitsSection) HasMultipleTypes() bool {
 	return len(s.Types) > 1
 }
+
+// ReleaseNoteTypeItems commits of a single type within a commits section.
+type ReleaseNoteTypeItems struct {
+	Type  string
+	Items []CommitLog
+}
+
+// ItemsByType return the items grouped by commit type in the order of Types, types without items are
+// omitted. Items of types not listed in Types, e.g. on the other section, follow in order of appearance.
+func (s ReleaseNoteCommitsSection) ItemsByType() []ReleaseNoteTypeItems {
+	groups := make(map[string][]CommitLog)
+	order := slices.Clone(s.Types)
+
+	for _, item := range s.Items {
+		if _, exists := groups[item.Message.Type]; !exists && !slices.Contains(order, item.Message.Type) {
+			order = append(order, item.Message.Type)
+		}
+
+		groups[item.Message.Type] = append(groups[item.Message.Type], item)
+	}
+
+	result := make([]ReleaseNoteTypeItems, 0, len(groups))
+
+	for _, commitType := range order {
+		if items, exists := groups[commitType]; exists {
+			result = append(result, ReleaseNoteTypeItems{Type: commitType, Items: items})
+			delete(groups, commitType)
+		}
+	}
+
+	return result
+}
+
+// CommitURL return the url of the commit hash, empty if no commit url template is configured.
+func (s ReleaseNoteCommitsSection) CommitURL(hash string) string {
+	if s.URLTemplate == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(s.URLTemplate, CommitURLHashPlaceholder, hash)
+}
+
+// ItemIssue return the issue of the item at index, if collapse issues is enabled
+// return empty when a previous item already references the same issue.
+func (s ReleaseNoteCommitsSection) ItemIssue(index int) string {
+	if index < 0 || index >= len(s.Items) {
+		return ""
+	}
+
+	issue := s.Items[index].Message.Issue()
+	if !s.CollapseIssues || issue == "" {
+		return issue
+	}
+
+	for _, item := range s.Items[:index] {
+		if item.Message.Issue() == issue {
+			return ""
+		}
+	}
+
+	return issue
+}
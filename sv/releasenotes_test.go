@@ -74,7 +74,13 @@ func TestBaseReleaseNoteProcessor_Create(t *testing.T) {
 					TestNewReleaseNoteCommitsSection(
 						"Tag 1", []string{"t1"}, []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
 					),
-					ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"breaks"}},
+					ReleaseNoteBreakingChangeSection{
+						Name:     "Breaking Changes",
+						Messages: []string{"breaks"},
+						Items: []CommitLog{
+							TestCommitlog("unmapped", map[string]string{"breaking-change": "breaks"}, "a"),
+						},
+					},
 				},
 				map[string]struct{}{"a": {}},
 			),
@@ -120,3 +126,508 @@ func TestBaseReleaseNoteProcessor_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseReleaseNoteProcessor_CreateOtherSection(t *testing.T) {
+	date := time.Now()
+
+	tests := []struct {
+		name     string
+		sections []ReleaseNotesSectionConfig
+		commits  []CommitLog
+		want     []ReleaseNoteSection
+	}{
+		{
+			name: "unmapped commits on other section",
+			sections: []ReleaseNotesSectionConfig{
+				{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}},
+				{Name: "Other Changes", SectionType: "other"},
+			},
+			commits: []CommitLog{
+				TestCommitlog("t1", map[string]string{}, "a"),
+				TestCommitlog("unmapped", map[string]string{}, "a"),
+				TestCommitlog("chore", map[string]string{}, "a"),
+			},
+			want: []ReleaseNoteSection{
+				TestNewReleaseNoteCommitsSection(
+					"Tag 1", []string{"t1"}, []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
+				),
+				TestNewReleaseNoteCommitsSection("Other Changes", nil, []CommitLog{
+					TestCommitlog("unmapped", map[string]string{}, "a"),
+					TestCommitlog("chore", map[string]string{}, "a"),
+				}),
+			},
+		},
+		{
+			name: "unmapped commits dropped without other section",
+			sections: []ReleaseNotesSectionConfig{
+				{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}},
+			},
+			commits: []CommitLog{
+				TestCommitlog("t1", map[string]string{}, "a"),
+				TestCommitlog("unmapped", map[string]string{}, "a"),
+			},
+			want: []ReleaseNoteSection{
+				TestNewReleaseNoteCommitsSection(
+					"Tag 1", []string{"t1"}, []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
+				),
+			},
+		},
+		{
+			name: "no other section without unmapped commits",
+			sections: []ReleaseNotesSectionConfig{
+				{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}},
+				{Name: "Other Changes", SectionType: "other"},
+			},
+			commits: []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
+			want: []ReleaseNoteSection{
+				TestNewReleaseNoteCommitsSection(
+					"Tag 1", []string{"t1"}, []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: tt.sections})
+			if got := p.Create(nil, "", date, tt.commits); !reflect.DeepEqual(got.Sections, tt.want) {
+				t.Errorf("BaseReleaseNoteProcessor.Create() = %v, want %v", got.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateRevertsSection(t *testing.T) {
+	date := time.Now()
+	feat := TestCommitlog("feat", map[string]string{}, "a")
+	revert := TestCommitlog("revert", map[string]string{}, "a")
+
+	message, err := NewMessageProcessor(ccfg, newBranchCfg(false)).Parse(`Revert "feat: something"`, "")
+	if err != nil {
+		t.Fatalf("BaseMessageProcessor.Parse() error = %v", err)
+	}
+
+	gitRevert := CommitLog{Message: message, AuthorName: "a"}
+
+	tests := []struct {
+		name     string
+		sections []ReleaseNotesSectionConfig
+		want     []ReleaseNoteSection
+	}{
+		{
+			name: "reverts on own section",
+			sections: []ReleaseNotesSectionConfig{
+				{Name: "Features", SectionType: "commits", CommitTypes: []string{"feat"}},
+				{Name: "Reverts", SectionType: "reverts"},
+			},
+			want: []ReleaseNoteSection{
+				TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []CommitLog{feat}),
+				TestNewReleaseNoteCommitsSection("Reverts", nil, []CommitLog{revert, gitRevert}),
+			},
+		},
+		{
+			name: "reverts without reverts section",
+			sections: []ReleaseNotesSectionConfig{
+				{Name: "Features", SectionType: "commits", CommitTypes: []string{"feat"}},
+			},
+			want: []ReleaseNoteSection{
+				TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []CommitLog{feat}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: tt.sections})
+			if got := p.Create(nil, "", date, []CommitLog{feat, revert, gitRevert}); !reflect.DeepEqual(got.Sections, tt.want) {
+				t.Errorf("BaseReleaseNoteProcessor.Create() = %v, want %v", got.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateAuthorsEmails(t *testing.T) {
+	withEmail := TestCommitlog("t1", map[string]string{}, "author1")
+	withEmail.AuthorEmail = "author1@example.com"
+
+	commits := []CommitLog{withEmail, TestCommitlog("t1", map[string]string{}, "author2")}
+	want := map[string]string{"author1": "author1@example.com"}
+
+	p := NewReleaseNoteProcessor(ReleaseNotesConfig{})
+	if got := p.Create(nil, "", time.Now(), commits); !reflect.DeepEqual(got.AuthorsEmails, want) {
+		t.Errorf("BaseReleaseNoteProcessor.Create() AuthorsEmails = %v, want %v", got.AuthorsEmails, want)
+	}
+}
+
+func TestReleaseNote_BreakingChangesOnly(t *testing.T) {
+	breaking := ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"breaks"}}
+	commits := TestNewReleaseNoteCommitsSection(
+		"Tag 1", []string{"t1"}, []CommitLog{TestCommitlog("t1", map[string]string{}, "a")},
+	)
+
+	tests := []struct {
+		name     string
+		sections []ReleaseNoteSection
+		want     []ReleaseNoteSection
+	}{
+		{"with breaking changes", []ReleaseNoteSection{commits, breaking}, []ReleaseNoteSection{breaking}},
+		{"without breaking changes", []ReleaseNoteSection{commits}, []ReleaseNoteSection{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note := ReleaseNote{Sections: tt.sections}
+			if got := note.BreakingChangesOnly(); !reflect.DeepEqual(got.Sections, tt.want) {
+				t.Errorf("ReleaseNote.BreakingChangesOnly() = %v, want %v", got.Sections, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteCommitsSection_ItemIssue(t *testing.T) {
+	items := []CommitLog{
+		TestCommitlog("fix", map[string]string{IssueMetadataKey: "JIRA-1"}, "a"),
+		TestCommitlog("fix", map[string]string{IssueMetadataKey: "JIRA-1"}, "a"),
+		TestCommitlog("fix", map[string]string{}, "a"),
+		TestCommitlog("fix", map[string]string{IssueMetadataKey: "JIRA-2"}, "a"),
+	}
+
+	tests := []struct {
+		name     string
+		collapse bool
+		want     []string
+	}{
+		{"without collapse", false, []string{"JIRA-1", "JIRA-1", "", "JIRA-2", ""}},
+		{"with collapse", true, []string{"JIRA-1", "", "", "JIRA-2", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := ReleaseNoteCommitsSection{Name: "Bug Fixes", Items: items, CollapseIssues: tt.collapse}
+
+			got := make([]string, len(tt.want))
+			for i := range got {
+				got[i] = section.ItemIssue(i)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReleaseNoteCommitsSection.ItemIssue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteCommitsSection_ItemsByType(t *testing.T) {
+	fix1 := TestCommitlog("fix", map[string]string{}, "a")
+	perf := TestCommitlog("perf", map[string]string{}, "b")
+	fix2 := TestCommitlog("fix", map[string]string{}, "c")
+	docs := TestCommitlog("docs", map[string]string{}, "d")
+
+	tests := []struct {
+		name  string
+		types []string
+		items []CommitLog
+		want  []ReleaseNoteTypeItems
+	}{
+		{
+			"configured type order",
+			[]string{"fix", "perf", "refactor"},
+			[]CommitLog{perf, fix1, fix2},
+			[]ReleaseNoteTypeItems{
+				{Type: "fix", Items: []CommitLog{fix1, fix2}},
+				{Type: "perf", Items: []CommitLog{perf}},
+			},
+		},
+		{
+			"unlisted types last",
+			[]string{"perf"},
+			[]CommitLog{docs, fix1, perf},
+			[]ReleaseNoteTypeItems{
+				{Type: "perf", Items: []CommitLog{perf}},
+				{Type: "docs", Items: []CommitLog{docs}},
+				{Type: "fix", Items: []CommitLog{fix1}},
+			},
+		},
+		{"without items", []string{"fix", "perf"}, nil, []ReleaseNoteTypeItems{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := ReleaseNoteCommitsSection{Name: "Fixes", Types: tt.types, Items: tt.items}
+			if got := section.ItemsByType(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReleaseNoteCommitsSection.ItemsByType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNoteCommitsSection_CommitURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlTemplate string
+		want        string
+	}{
+		{"without template", "", ""},
+		{"with template", "https://example.com/commit/{hash}", "https://example.com/commit/abc123"},
+		{"without placeholder", "https://example.com/commits", "https://example.com/commits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := ReleaseNoteCommitsSection{URLTemplate: tt.urlTemplate}
+			if got := section.CommitURL("abc123"); got != tt.want {
+				t.Errorf("ReleaseNoteCommitsSection.CommitURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateCollapseIssues(t *testing.T) {
+	p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: []ReleaseNotesSectionConfig{
+		{Name: "Bug Fixes", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}, CollapseIssues: true},
+	}})
+
+	got := p.Create(nil, "", time.Now(), []CommitLog{TestCommitlog("fix", map[string]string{}, "a")})
+	if len(got.Sections) != 1 || !got.Sections[0].(ReleaseNoteCommitsSection).CollapseIssues {
+		t.Errorf("BaseReleaseNoteProcessor.Create() = %v, want section with collapse issues", got.Sections)
+	}
+}
+
+func TestReleaseNotesConfig_DroppedCommits(t *testing.T) {
+	feat := TestCommitlog("feat", map[string]string{}, "a")
+	docs := TestCommitlog("docs", map[string]string{}, "a")
+	breaking := TestCommitlog("docs", map[string]string{BreakingChangeMetadataKey: "breaks"}, "a")
+	commits := []CommitLog{feat, docs, breaking}
+
+	featSection := ReleaseNotesSectionConfig{
+		Name: "Features", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"},
+	}
+
+	tests := []struct {
+		name     string
+		sections []ReleaseNotesSectionConfig
+		want     []CommitLog
+	}{
+		{"unmapped types", []ReleaseNotesSectionConfig{featSection}, []CommitLog{docs, breaking}},
+		{
+			"breaking changes section",
+			[]ReleaseNotesSectionConfig{featSection, {Name: "Breaking", SectionType: ReleaseNotesSectionTypeBreakingChanges}},
+			[]CommitLog{docs},
+		},
+		{
+			"other section",
+			[]ReleaseNotesSectionConfig{featSection, {Name: "Other", SectionType: ReleaseNotesSectionTypeOther}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ReleaseNotesConfig{Sections: tt.sections}
+			if got := cfg.DroppedCommits(commits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReleaseNotesConfig.DroppedCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateEmoji(t *testing.T) {
+	p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: []ReleaseNotesSectionConfig{
+		{Name: "Features", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}, Emoji: "✨"},
+		{Name: "Bug Fixes", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+		{Name: "Breaking Changes", SectionType: ReleaseNotesSectionTypeBreakingChanges, Emoji: "💥"},
+	}})
+
+	commits := []CommitLog{
+		TestCommitlog("feat", map[string]string{BreakingChangeMetadataKey: "breaks"}, "a"),
+		TestCommitlog("fix", map[string]string{}, "a"),
+	}
+
+	got := p.Create(nil, "", time.Now(), commits)
+	want := []string{"✨", "", "💥"}
+
+	for i, section := range got.Sections {
+		var emoji string
+
+		switch s := section.(type) {
+		case ReleaseNoteCommitsSection:
+			emoji = s.Emoji
+		case ReleaseNoteBreakingChangeSection:
+			emoji = s.Emoji
+		}
+
+		if emoji != want[i] {
+			t.Errorf("BaseReleaseNoteProcessor.Create() section %s emoji = %v, want %v", section.SectionName(), emoji, want[i])
+		}
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateSectionOrder(t *testing.T) {
+	sections := []ReleaseNotesSectionConfig{
+		{Name: "Features", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+		{Name: "Bug Fixes", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+		{Name: "Performance", SectionType: ReleaseNotesSectionTypeCommits, CommitTypes: []string{"perf"}},
+		{Name: "Breaking Changes", SectionType: ReleaseNotesSectionTypeBreakingChanges},
+	}
+	commits := []CommitLog{
+		TestCommitlog("feat", map[string]string{BreakingChangeMetadataKey: "breaks"}, "a"),
+		TestCommitlog("fix", map[string]string{}, "a"),
+		TestCommitlog("perf", map[string]string{}, "a"),
+	}
+
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"config order", nil, []string{"Features", "Bug Fixes", "Performance", "Breaking Changes"}},
+		{
+			"all sections listed",
+			[]string{"Breaking Changes", "Bug Fixes", "Performance", "Features"},
+			[]string{"Breaking Changes", "Bug Fixes", "Performance", "Features"},
+		},
+		{
+			"unlisted sections after listed",
+			[]string{"Performance", "Breaking Changes"},
+			[]string{"Performance", "Breaking Changes", "Features", "Bug Fixes"},
+		},
+		{
+			"unknown sections ignored",
+			[]string{"Unknown", "Bug Fixes"},
+			[]string{"Bug Fixes", "Features", "Performance", "Breaking Changes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: sections, SectionOrder: tt.order})
+			got := p.Create(nil, "", time.Now(), commits)
+
+			names := make([]string, 0, len(got.Sections))
+			for _, section := range got.Sections {
+				names = append(names, section.SectionName())
+			}
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("BaseReleaseNoteProcessor.Create() sections = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseReleaseNoteProcessor_CreateEmpty(t *testing.T) {
+	date := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := NewReleaseNoteProcessor(ReleaseNotesConfig{
+		Sections:     []ReleaseNotesSectionConfig{{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}}},
+		EmptyMessage: "No changes.",
+	})
+
+	got := p.Create(nil, "", LatestCommitDate(nil, date), nil)
+
+	if !got.Date.Equal(date) {
+		t.Errorf("BaseReleaseNoteProcessor.Create() Date = %v, want %v", got.Date, date)
+	}
+
+	if len(got.Sections) != 0 || got.EmptyMessage != "No changes." {
+		t.Errorf("BaseReleaseNoteProcessor.Create() = %v, want no sections and empty message", got)
+	}
+}
+
+func TestReleaseNotesConfig_Compare(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlTemplate string
+		want        string
+	}{
+		{"without template", "", "1.0.0...1.1.0"},
+		{
+			"with template",
+			"https://github.com/owner/repo/compare/{from}...{to}",
+			"https://github.com/owner/repo/compare/1.0.0...1.1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ReleaseNotesConfig{CompareURLTemplate: tt.urlTemplate}
+			if got := cfg.Compare("1.0.0", "1.1.0"); got != tt.want {
+				t.Errorf("ReleaseNotesConfig.Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseNotesConfig_DuplicatedCommitTypes(t *testing.T) {
+	cfg := ReleaseNotesConfig{Sections: []ReleaseNotesSectionConfig{
+		{Name: "Features", SectionType: "commits", CommitTypes: []string{"feat"}},
+		{Name: "Fixes", SectionType: "commits", CommitTypes: []string{"fix", "perf"}},
+		{Name: "Performance", SectionType: "commits", CommitTypes: []string{"perf"}},
+		{Name: "Breaking Changes", SectionType: "breaking-changes"},
+	}}
+
+	want := map[string][]string{"perf": {"Fixes", "Performance"}}
+	if got := cfg.DuplicatedCommitTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReleaseNotesConfig.DuplicatedCommitTypes() = %v, want %v", got, want)
+	}
+
+	for i := 0; i < 10; i++ {
+		rn := NewReleaseNoteProcessor(cfg).Create(nil, "", time.Now(), []CommitLog{
+			TestCommitlog("perf", map[string]string{}, "a"),
+		})
+		if len(rn.Sections) != 1 || rn.Sections[0].SectionName() != "Performance" {
+			t.Fatalf("BaseReleaseNoteProcessor.Create() = %v, want commit on last matching section", rn.Sections)
+		}
+	}
+}
+
+func TestMergeReleaseNotes(t *testing.T) {
+	date := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	version := semver.MustParse("1.1.0")
+
+	feat := TestCommitlog("feat", map[string]string{}, "a")
+	fix := TestCommitlog("fix", map[string]string{}, "b")
+	perf := TestCommitlog("perf", map[string]string{}, "c")
+	breaking := TestCommitlog("feat", map[string]string{BreakingChangeMetadataKey: "breaks"}, "c")
+	breakingSection := ReleaseNoteBreakingChangeSection{
+		Name: "Breaking Changes", Messages: []string{"breaks"}, Items: []CommitLog{breaking},
+	}
+
+	first := ReleaseNote{
+		Version: version,
+		Tag:     "1.1.0",
+		Date:    date,
+		Sections: []ReleaseNoteSection{
+			TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []CommitLog{feat}),
+			TestNewReleaseNoteCommitsSection("Bug Fixes", []string{"fix"}, []CommitLog{fix}),
+		},
+		AuthorsNames:  map[string]struct{}{"a": {}, "b": {}},
+		AuthorsEmails: map[string]string{"a": "a@example.com"},
+	}
+	second := ReleaseNote{
+		Version: semver.MustParse("2.0.0"),
+		Tag:     "2.0.0",
+		Date:    date.Add(time.Hour),
+		Sections: []ReleaseNoteSection{
+			TestNewReleaseNoteCommitsSection("Bug Fixes", []string{"fix", "perf"}, []CommitLog{fix, perf}),
+			breakingSection,
+		},
+		AuthorsNames:  map[string]struct{}{"b": {}, "c": {}},
+		AuthorsEmails: map[string]string{"a": "other@example.com", "c": "c@example.com"},
+	}
+
+	want := ReleaseNote{
+		Version: version,
+		Tag:     "1.1.0",
+		Date:    date,
+		Sections: []ReleaseNoteSection{
+			TestNewReleaseNoteCommitsSection("Features", []string{"feat"}, []CommitLog{feat}),
+			TestNewReleaseNoteCommitsSection("Bug Fixes", []string{"fix", "perf"}, []CommitLog{fix, fix, perf}),
+			breakingSection,
+		},
+		AuthorsNames:  map[string]struct{}{"a": {}, "b": {}, "c": {}},
+		AuthorsEmails: map[string]string{"a": "a@example.com", "c": "c@example.com"},
+	}
+
+	if got := MergeReleaseNotes(first, second); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeReleaseNotes() = %v, want %v", got, want)
+	}
+
+	if got := first.Sections[1].(ReleaseNoteCommitsSection).Items; len(got) != 1 {
+		t.Errorf("MergeReleaseNotes() modified source section items = %v", got)
+	}
+
+	if got := MergeReleaseNotes(); !reflect.DeepEqual(got, ReleaseNote{}) {
+		t.Errorf("MergeReleaseNotes() = %v, want empty release note", got)
+	}
+}
@@ -37,11 +37,12 @@ func TestReleaseNote(
 	authorsNames map[string]struct{},
 ) ReleaseNote {
 	return ReleaseNote{
-		Version:      version,
-		Tag:          tag,
-		Date:         date.Truncate(time.Minute),
-		Sections:     sections,
-		AuthorsNames: authorsNames,
+		Version:       version,
+		Tag:           tag,
+		Date:          date.Truncate(time.Minute),
+		Sections:      sections,
+		AuthorsNames:  authorsNames,
+		AuthorsEmails: map[string]string{},
 	}
 }
 
@@ -0,0 +1,23 @@
+{{- range $section := .Sections }}
+{{- if (eq $section.SectionType "commits") }}
+{{- template "rn-md-section-commits.tpl" $section }}
+{{- else if (eq $section.SectionType "breaking-changes") }}
+{{- template "rn-md-section-breaking-changes.tpl" $section }}
+{{- end }}
+{{- end }}
+{{- if and (not .Sections) .EmptyMessage }}
+
+{{ .EmptyMessage }}
+{{- end }}
+{{- with .Stats }}
+
+{{ . }}
+{{- end }}
+{{- if .AuthorNames }}
+
+**Contributors**: {{ join ", " .AuthorNames }}
+{{- end }}
+{{- if .Compare }}
+
+**Full Changelog**: {{ .Compare }}
+{{- end -}}
@@ -5,4 +5,12 @@
 {{- else if (eq $section.SectionType "breaking-changes") }}
 {{- template "rn-md-section-breaking-changes.tpl" $section }}
 {{- end }}
+{{- end }}
+{{- if and (not .Sections) .EmptyMessage }}
+
+{{ .EmptyMessage }}
+{{- end }}
+{{- with .Stats }}
+
+{{ . }}
 {{- end -}}
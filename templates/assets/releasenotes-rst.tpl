@@ -0,0 +1,20 @@
+{{- $title := .Release }}
+{{- $date := .Date | date "2006-01-02" }}
+{{- if and $title $date }}{{ $title = printf "%s (%s)" $title $date }}{{ else if $date }}{{ $title = $date }}{{ end -}}
+{{ $title }}
+{{ underline "=" $title }}
+{{- range $section := .Sections }}
+{{- if (eq $section.SectionType "commits") }}
+{{- template "rn-rst-section-commits.tpl" $section }}
+{{- else if (eq $section.SectionType "breaking-changes") }}
+{{- template "rn-rst-section-breaking-changes.tpl" $section }}
+{{- end }}
+{{- end }}
+{{- if and (not .Sections) .EmptyMessage }}
+
+{{ .EmptyMessage }}
+{{- end }}
+{{- with .Stats }}
+
+{{ . }}
+{{- end -}}
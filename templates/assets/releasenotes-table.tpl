@@ -0,0 +1,21 @@
+## {{ if .Release }}{{ .Release }}{{ end }}{{ if and (not .Date.IsZero) .Release }} ({{ end }}{{ .Date | date "2006-01-02" }}{{ if and (not .Date.IsZero) .Release }}){{ end }}
+{{- if .Sections }}
+
+| Type | Scope | Description | Author | Hash |
+| ---- | ----- | ----------- | ------ | ---- |
+{{- range $section := .Sections }}
+{{- if (eq $section.SectionType "commits") }}
+{{- range $v := $section.Items }}
+| {{ $v.Message.Type }} | {{ tableCell $v.Message.Scope }} | {{ tableCell $v.Message.Description }} | {{ tableCell $v.AuthorName }} | {{ $v.Hash }} |
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if and (not .Sections) .EmptyMessage }}
+
+{{ .EmptyMessage }}
+{{- end }}
+{{- with .Stats }}
+
+{{ . }}
+{{- end -}}
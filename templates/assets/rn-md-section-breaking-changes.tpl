@@ -1,7 +1,7 @@
 {{- if ne .Name "" }}
 
-### {{ .Name }}
+### {{ if .Emoji }}{{ .Emoji }} {{ end }}{{ .Name }}
 {{ range $k,$v := .Messages }}
-- {{ $v }}
+- {{ $v }}{{ if and $.ShowHash (lt $k (len $.Items)) }} ({{ (index $.Items $k).Hash }}){{ end }}
 {{- end }}
 {{- end -}}
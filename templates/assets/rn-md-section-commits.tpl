@@ -1,8 +1,17 @@
 {{- if . }}{{- if ne .SectionName "" }}
 
-### {{ .SectionName }}
+### {{ if .Emoji }}{{ .Emoji }} {{ end }}{{ .SectionName }}
+{{- if .Collapsed }}
+
+<details>
+<summary>{{ len .Items }} changes</summary>
+{{- end }}
 {{ range $k,$v := .Items }}
-- {{ if $v.Message.Scope }}**{{ $v.Message.Scope }}:** {{ end }}{{ $v.Message.Description }} ({{ $v.Hash }}){{ if $v.Message.Metadata.issue }} ({{ $v.Message.Metadata.issue }}){{ end }}
+- {{ scopePrefix $v.Message.Scope }}{{ $v.Message.Description }} ({{ with $.CommitURL $v.Hash }}[{{ $v.Hash }}]({{ . }}){{ else }}{{ $v.Hash }}{{ end }}){{ with $v.Message.PR }} (#{{ . }}){{ end }}{{ with $.ItemIssue $k }} ({{ . }}){{ end }}
+{{- end }}
+{{- if .Collapsed }}
+
+</details>
 {{- end }}
 {{- end }}
 {{- end -}}
@@ -0,0 +1,9 @@
+{{- if ne .Name "" }}
+{{- $name := .Name }}{{ if .Emoji }}{{ $name = printf "%s %s" .Emoji .Name }}{{ end }}
+
+{{ $name }}
+{{ underline "~" $name }}
+{{ range $k,$v := .Messages }}
+- {{ $v }}{{ if and $.ShowHash (lt $k (len $.Items)) }} ({{ (index $.Items $k).Hash }}){{ end }}
+{{- end }}
+{{- end -}}
@@ -0,0 +1,10 @@
+{{- if . }}{{- if ne .SectionName "" }}
+{{- $name := .SectionName }}{{ if .Emoji }}{{ $name = printf "%s %s" .Emoji .SectionName }}{{ end }}
+
+{{ $name }}
+{{ underline "~" $name }}
+{{ range $k,$v := .Items }}
+- {{ scopePrefix $v.Message.Scope }}{{ $v.Message.Description }} ({{ with $.CommitURL $v.Hash }}`{{ $v.Hash }} <{{ . }}>`__{{ else }}{{ $v.Hash }}{{ end }}){{ with $v.Message.PR }} (#{{ . }}){{ end }}{{ with $.ItemIssue $k }} ({{ . }}){{ end }}
+{{- end }}
+{{- end }}
+{{- end -}}
@@ -4,8 +4,10 @@ import (
 	"embed"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/rs/zerolog/log"
@@ -15,14 +17,18 @@ import (
 //go:embed assets
 var templateFs embed.FS
 
-// New loads the template to make it parseable.
+// New loads the template to make it parseable, a relative config dir is resolved from the working directory.
 func New(configDir string) *template.Template {
-	workDir, err := os.Getwd()
-	if err != nil {
-		log.Fatal().Err(err).Msg("error while retrieving working directory")
-	}
+	tplsDir := filepath.Join(configDir, "templates")
+
+	if !filepath.IsAbs(configDir) {
+		workDir, err := os.Getwd()
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while retrieving working directory")
+		}
 
-	tplsDir := filepath.Join(workDir, configDir, "templates")
+		tplsDir = filepath.Join(workDir, tplsDir)
+	}
 
 	tpls, err := template.New("templates").Funcs(Funcs()).ParseFS(templateFs, "**/*.tpl")
 	if err != nil {
@@ -54,6 +60,9 @@ func Funcs() template.FuncMap {
 	functs := sprig.FuncMap()
 
 	functs["date"] = zeroDate
+	functs["underline"] = underline
+	functs["tableCell"] = tableCell
+	functs["scopePrefix"] = scopePrefix
 	// functs["getsection"] = getSection
 
 	return functs
@@ -67,6 +76,25 @@ func zeroDate(fmt string, date time.Time) string {
 	return date.Format(fmt)
 }
 
+// underline return char repeated to match the length of text, used for reStructuredText headers.
+func underline(char, text string) string {
+	return strings.Repeat(char, utf8.RuneCountInString(text))
+}
+
+// tableCell escape pipes of text to keep it within a single markdown table cell.
+func tableCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// scopePrefix return the scope as bold prefix of a commit description, empty if there is no scope.
+func scopePrefix(scope string) string {
+	if scope == "" {
+		return ""
+	}
+
+	return "**" + scope + ":** "
+}
+
 func getSection(name string, sections []sv.ReleaseNoteSection) sv.ReleaseNoteSection { //nolint:ireturn
 	for _, section := range sections {
 		if section.SectionName() == name {
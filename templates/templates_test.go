@@ -78,3 +78,60 @@ func Test_getSection(t *testing.T) {
 		})
 	}
 }
+
+func Test_underline(t *testing.T) {
+	tests := []struct {
+		name string
+		char string
+		text string
+		want string
+	}{
+		{"ascii", "=", "v1.0.0", "======"},
+		{"empty", "~", "", ""},
+		{"multibyte", "~", "Änderungen", "~~~~~~~~~~"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := underline(tt.char, tt.text); got != tt.want {
+				t.Errorf("underline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tableCell(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "add feature", "add feature"},
+		{"pipe", "support a|b", "support a\\|b"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tableCell(tt.text); got != tt.want {
+				t.Errorf("tableCell() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scopePrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope string
+		want  string
+	}{
+		{"scope", "api", "**api:** "},
+		{"without scope", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopePrefix(tt.scope); got != tt.want {
+				t.Errorf("scopePrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}